@@ -0,0 +1,166 @@
+"""
+Participation analytics aggregated over stored meetings.
+"""
+from collections import Counter
+from dataclasses import dataclass, field
+from datetime import datetime
+from typing import Iterable, List, Optional, Tuple
+
+from .models import Meeting
+
+# Blocker answers that mean "nothing is blocking me"
+EMPTY_BLOCKER_ANSWERS = {'', 'none', 'n/a', 'na', 'no', 'nothing', '-'}
+
+
+@dataclass
+class ParticipationStats:
+    """Aggregated engagement numbers for a time window."""
+    meetings_held: int = 0
+    total_attendance: int = 0
+    updates_by_user: Counter = field(default_factory=Counter)
+    rating_total: int = 0
+    rating_count: int = 0
+
+    @property
+    def average_attendance(self) -> float:
+        """Average number of distinct participants per meeting."""
+        if not self.meetings_held:
+            return 0.0
+        return self.total_attendance / self.meetings_held
+
+    @property
+    def average_rating(self) -> Optional[float]:
+        """Average 1-5 rating across every rating left on these meetings."""
+        if not self.rating_count:
+            return None
+        return self.rating_total / self.rating_count
+
+    def most_active(self, limit: int = 3) -> List[Tuple[str, int]]:
+        """Users with the most submitted updates."""
+        return self.updates_by_user.most_common(limit)
+
+    def least_active(self, limit: int = 3) -> List[Tuple[str, int]]:
+        """Users with the fewest submitted updates (among those who submitted any)."""
+        return sorted(self.updates_by_user.items(), key=lambda item: (item[1], item[0]))[:limit]
+
+
+def compute_participation(meetings: Iterable[Meeting], since: datetime) -> ParticipationStats:
+    """
+    Aggregate participation for meetings created on or after `since`.
+
+    Meetings are consumed one at a time so the full history never needs to be in memory.
+    """
+    stats = ParticipationStats()
+    for meeting in meetings:
+        if datetime.fromisoformat(meeting.created_at) < since:
+            continue
+
+        stats.meetings_held += 1
+        stats.total_attendance += len({update.user for update in meeting.updates})
+        if not meeting.anonymous_updates:
+            stats.updates_by_user.update(update.user for update in meeting.updates)
+        stats.rating_total += sum(rating.score for rating in meeting.ratings)
+        stats.rating_count += len(meeting.ratings)
+
+    return stats
+
+
+def extract_blockers(meeting: Meeting) -> List[Tuple[str, str]]:
+    """List (author, blockers) for each update that reports an actual blocker."""
+    return [
+        (meeting.update_author(update), update.blockers.strip())
+        for update in meeting.updates
+        if update.blockers.strip().lower().rstrip('.!') not in EMPTY_BLOCKER_ANSWERS
+    ]
+
+
+@dataclass
+class SeriesOccurrence:
+    """One meeting in a series and how many people took part."""
+    meeting_id: str
+    created_at: str
+    participants: int
+    expected: int
+
+
+@dataclass
+class SeriesStats:
+    """Attendance and blocker patterns across the meetings in a series."""
+    occurrences: List[SeriesOccurrence] = field(default_factory=list)
+    recurring_blockers: List[Tuple[str, int]] = field(default_factory=list)
+
+    @property
+    def submission_rate(self) -> Optional[float]:
+        """Share of rostered members who posted, over meetings that had a roster."""
+        expected = sum(occurrence.expected for occurrence in self.occurrences)
+        if not expected:
+            return None
+        submitted = sum(min(occurrence.participants, occurrence.expected) for occurrence in self.occurrences if occurrence.expected)
+        return submitted / expected
+
+
+def compute_series_stats(meetings: Iterable[Meeting], limit: int = 5) -> SeriesStats:
+    """
+    Summarize a series of meetings, oldest first.
+
+    A blocker counts as recurring when the same text (ignoring case) is reported
+    in more than one of the meetings.
+    """
+    stats = SeriesStats()
+    blocker_meetings: Counter = Counter()
+    for meeting in sorted(meetings, key=lambda meeting: meeting.created_at):
+        submitted = {update.user for update in meeting.updates}
+        stats.occurrences.append(SeriesOccurrence(
+            meeting_id=meeting.id,
+            created_at=meeting.created_at,
+            participants=len(submitted),
+            expected=len(meeting.roster)
+        ))
+        blocker_meetings.update({blockers.lower() for _, blockers in extract_blockers(meeting)})
+
+    stats.recurring_blockers = [(text, count) for text, count in blocker_meetings.most_common(limit) if count > 1]
+    return stats
+
+
+@dataclass
+class AttendanceRecord:
+    """
+    Whether one expected or actual participant posted to a meeting, and when.
+
+    On meetings with anonymous updates, who posted isn't known, so `updates` is None.
+    """
+    user: str
+    on_roster: bool
+    updates: Optional[int]
+    first_update_at: Optional[str]
+
+
+def compute_attendance(meeting: Meeting) -> List[AttendanceRecord]:
+    """
+    List the meeting's rostered members, then anyone else who posted, treating a
+    submitted update as having attended.
+
+    Meetings with anonymous updates list only the roster, without who posted.
+    """
+    if meeting.anonymous_updates:
+        return [
+            AttendanceRecord(user=user, on_roster=True, updates=None, first_update_at=None)
+            for user in meeting.roster
+        ]
+
+    first_update: dict = {}
+    counts: Counter = Counter()
+    for update in sorted(meeting.updates, key=lambda update: update.timestamp):
+        first_update.setdefault(update.user, update.timestamp)
+        counts[update.user] += 1
+
+    users = list(meeting.roster) + [user for user in first_update if user not in meeting.roster]
+    return [
+        AttendanceRecord(
+            user=user,
+            on_roster=user in meeting.roster,
+            updates=counts[user],
+            first_update_at=first_update.get(user)
+        )
+        for user in users
+    ]
@@ -1,17 +1,120 @@
 """
 Main Discord bot implementation for the meeting bot.
 """
+import io
 import os
+import re
+import json
+import time
+import hashlib
+import asyncio
 import discord
-from discord.ext import commands
+import segno
+from discord.ext import commands, tasks
 from discord import app_commands
 from dotenv import load_dotenv
-from typing import Optional
+from datetime import datetime, timedelta, timezone
+from dataclasses import dataclass
+from typing import Awaitable, Callable, Dict, List, Optional, Tuple
 
-from .models import Meeting, Update
-from .storage import MeetingStorage
+from .models import Meeting, Announcement, Update, Rating, AuditEvent, MeetingChange, GuildSettings, parse_tags, toggle_optional_field, PUBLIC, PRIVATE, PENDING, ANONYMOUS_AUTHOR, UPDATE_FIELDS
+from .storage import MeetingStorage, StorageUnavailableError, MeetingNotFoundError, MeetingClosedError, DuplicateMeetingError, ConcurrentModificationError
 from .s3_storage import S3Storage
 from .report_generator import ReportGenerator
+from .pagination import PaginatedEmbedView
+from .views import ExpiringView, is_expired_custom_id
+from .analytics import compute_participation, compute_series_stats, extract_blockers
+from .command_validation import validate_commands
+from .config import get_max_length, get_bool, get_choice, is_valid_url, parse_times, validate_env, SUMMARY_DESTINATIONS, DEFAULT_DIGEST_TIMES
+from .content_filter import ContentFilter, MASK
+from .ics_import import parse_ics
+from .dm import send_batch_dms
+from .gist import GistError, create_gist, get_gist_token
+from .formatting import sanitize_for_embed, sanitize_list, sanitize_code, truncate
+from .tracing import start_request, log
+
+# Storage calls that take longer than this are treated as failures
+STORAGE_TIMEOUT_SECONDS = 5
+STORAGE_UNAVAILABLE_MESSAGE = "⚠️ Meeting data is temporarily unavailable, please try again."
+DISABLED_MESSAGE = "🛠️ The meeting bot is temporarily disabled in this server."
+EXPIRED_INTERACTION_MESSAGE = "⌛ This action has expired, please run the command again."
+BLOCKED_CONTENT_MESSAGE = "⚠️ Your meeting contains blocked words. Please rephrase it and try again."
+
+# Actions that need Manage Events live on their own command, so servers can restrict it
+# through Discord's integration settings
+MANAGE_COMMAND_NAME = "meetingbot-manage"
+
+# Discord's error code for responding to an interaction that was already acknowledged
+INTERACTION_ALREADY_ACKNOWLEDGED = 40060
+
+# Caps on .ics imports so one upload can't flood storage
+MAX_ICS_BYTES = 256 * 1024
+MAX_ICS_IMPORTS = 25
+
+# S3 caps presigned URLs at 7 days
+SHARE_LINK_DEFAULT_HOURS = 24
+SHARE_LINK_MAX_HOURS = 7 * 24
+
+# Locales that write dates month-first or year-first; everyone else is read day-first
+MONTH_FIRST_LOCALES = {discord.Locale.american_english}
+YEAR_FIRST_LOCALES = {discord.Locale.chinese, discord.Locale.taiwan_chinese, discord.Locale.japanese, discord.Locale.korean}
+
+# Meetings shown in the series-stats participant trend
+SERIES_TREND_LENGTH = 10
+
+# Keeps a server's list of scheduled announcements to a couple of pages
+MAX_PENDING_ANNOUNCEMENTS = 25
+ANNOUNCEMENT_PREVIEW_LENGTH = 80
+
+# Each edited value is cut to this in change listings, so an edit with every
+# field changed still fits in one message
+CHANGE_VALUE_PREVIEW_LENGTH = 200
+
+# Longer debug dumps are sent as a file, leaving room for the header and code fence
+DEBUG_INLINE_LIMIT = 1800
+
+# Keeps the digest embed well under Discord's description limit
+DIGEST_MAX_MEETINGS = 20
+
+# Discord rate limits presence updates, so refreshes are spaced out
+PRESENCE_MIN_INTERVAL_SECONDS = 30
+DEFAULT_PRESENCE_TEMPLATE = "{count} open meetings"
+
+
+@dataclass
+class CommandOptions:
+    """Options passed to /meetingbot, shared by every action handler."""
+    meeting_id: Optional[str] = None
+    days: Optional[int] = None
+    tag: Optional[str] = None
+    archived: bool = False
+    visibility: Optional[str] = None
+    user: Optional[discord.Member] = None
+    text: Optional[str] = None
+    file: Optional[discord.Attachment] = None
+    compact: Optional[bool] = None
+    anonymous: bool = False
+    role: Optional[discord.Role] = None
+    start: Optional[str] = None
+    end: Optional[str] = None
+    target: Optional[str] = None
+    field: Optional[str] = None
+    scope: Optional[str] = None
+    at: Optional[str] = None
+    channel: Optional[discord.TextChannel] = None
+    announcement_id: Optional[str] = None
+
+
+ActionHandler = Callable[[discord.Interaction, CommandOptions], Awaitable[None]]
+
+
+@dataclass
+class RegisteredAction:
+    """A /meetingbot action and how to dispatch it."""
+    handler: ActionHandler
+    requires_meeting_id: bool = False
+    # Dispatched from /meetingbot-manage instead of /meetingbot
+    manager: bool = False
 
 
 class MeetingBot(commands.Bot):
@@ -20,12 +123,58 @@ class MeetingBot(commands.Bot):
     def __init__(self):
         intents = discord.Intents.default()
         intents.message_content = True
-        super().__init__(command_prefix='!', intents=intents)
+        # Privileged; role rosters and name lookups only see members the bot has cached
+        intents.members = True
+        # Nothing the bot sends pings anyone unless a send opts in (e.g. CREATE_MENTION)
+        super().__init__(command_prefix='!', intents=intents, allowed_mentions=discord.AllowedMentions.none())
         
         self.storage = MeetingStorage()
         self.s3_storage = None  # Will be initialized after load_dotenv()
         self.report_generator = ReportGenerator()
         self.guild_id = None
+        self.storage_error_count = 0
+        self.default_meeting_link = None
+        self.content_filter: Optional[ContentFilter] = None
+        self.digest_channel_id: Optional[int] = None
+        # Set by the bot owner to halt scheduled posts during an incident; not persisted
+        self.schedulers_paused = False
+        self._last_presence_update = 0.0
+        self._presence_task = None
+        self.actions: Dict[str, RegisteredAction] = {}
+    
+    def register_action(self, name: str, requires_meeting_id: bool = False, manager: bool = False):
+        """Decorator registering a handler for `/meetingbot <name>`, or `/meetingbot-manage <name>` if `manager` is set."""
+        def decorator(handler: ActionHandler) -> ActionHandler:
+            self.actions[name] = RegisteredAction(handler=handler, requires_meeting_id=requires_meeting_id, manager=manager)
+            return handler
+        return decorator
+    
+    async def dispatch_action(self, interaction: discord.Interaction, name: str, options: CommandOptions, manager: bool = False):
+        """Route a /meetingbot or /meetingbot-manage invocation to its registered handler."""
+        action = self.actions.get(name)
+        if not action:
+            await reply(interaction, f"❌ Unknown action `{name}`. Pick one from the suggestions.", ephemeral=True)
+            return
+        
+        if action.manager != manager:
+            command = MANAGE_COMMAND_NAME if action.manager else "meetingbot"
+            await reply(interaction, f"❌ `{name}` is part of `/{command}`.", ephemeral=True)
+            return
+        
+        if action.requires_meeting_id and not options.meeting_id:
+            await reply(interaction, f"❌ Meeting ID is required for {name} command.", ephemeral=True)
+            return
+        
+        if await reject_if_disabled(interaction):
+            return
+        
+        try:
+            await action.handler(interaction, options)
+        except Exception as e:
+            # Handlers report their own expected failures; this catches anything they missed
+            log(f"Unhandled error in action {name}: {e!r}")
+            if not interaction.response.is_done():
+                await reply(interaction, "❌ Something went wrong. Please try again.", ephemeral=True)
     
     def initialize_s3(self):
         """Initialize S3 storage after environment is loaded."""
@@ -37,6 +186,30 @@ class MeetingBot(commands.Bot):
         # Initialize S3 storage (dotenv already loaded in main())
         self.initialize_s3()
 
+        # Buttons on meeting cards keep working across restarts
+        self.add_dynamic_items(CopyLinkButton, RateMeetingButton, ReviewMeetingButton)
+
+        default_link = os.getenv('DEFAULT_MEETING_LINK', '').strip()
+        if default_link and is_valid_url(default_link):
+            self.default_meeting_link = default_link
+        elif default_link:
+            print("Warning: DEFAULT_MEETING_LINK is not a valid http(s) URL; ignoring it")
+
+        self.content_filter = ContentFilter.from_env()
+
+        digest_channel = os.getenv('DIGEST_CHANNEL_ID', '').strip()
+        if digest_channel:
+            try:
+                self.digest_channel_id = int(digest_channel)
+                self.post_open_meetings_digest.change_interval(
+                    time=parse_times(os.getenv('DIGEST_TIMES', '') or DEFAULT_DIGEST_TIMES)
+                )
+                self.post_open_meetings_digest.start()
+            except ValueError as e:
+                print(f"Warning: Open meetings digest disabled: {e}")
+
+        self.post_due_announcements.start()
+
         # Resolve guild IDs: prefer DISCORD_GUILD_IDS (comma-separated),
         # fall back to DISCORD_GUILD_ID, otherwise sync globally
         env_multi = os.getenv('DISCORD_GUILD_IDS', '')
@@ -49,167 +222,3191 @@ class MeetingBot(commands.Bot):
             except ValueError:
                 print("Warning: DISCORD_GUILD_ID is not a valid integer; will sync globally")
 
+        # Fail with a descriptive error instead of an opaque 400 from Discord
+        validate_commands(self.tree.get_commands())
+
         if guild_ids:
             # Per-guild sync for instant availability in each server
-            for gid in guild_ids:
-                guild = discord.Object(id=gid)
-                self.tree.copy_global_to(guild=guild)
-                await self.tree.sync(guild=guild)
-            print(f"Synced slash commands for guilds: {guild_ids}")
+            errors = await self.sync_guild_commands(guild_ids)
+            if errors:
+                # The bot still runs in the guilds that synced
+                print(f"Warning: {errors.message}")
         else:
             # Global sync (may take up to ~1 hour to propagate)
-            await self.tree.sync()
+            await self.sync_commands()
             print("Synced global slash commands")
     
+    async def sync_guild_commands(self, guild_ids: List[int]) -> Optional[ExceptionGroup]:
+        """
+        Sync commands to each guild, continuing past guilds that fail.
+        
+        Returns:
+            ExceptionGroup of the per-guild failures, or None if every guild synced
+        """
+        failures = []
+        for gid in guild_ids:
+            guild = discord.Object(id=gid)
+            self.tree.copy_global_to(guild=guild)
+            try:
+                await self.sync_commands(guild=guild)
+            except discord.HTTPException as e:
+                print(f"Warning: Could not sync commands for guild {gid}: {e}")
+                e.add_note(f"guild {gid}")
+                failures.append(e)
+        
+        print(f"Synced slash commands for {len(guild_ids) - len(failures)}/{len(guild_ids)} guilds: {guild_ids}")
+        if not failures:
+            return None
+        return ExceptionGroup(f"Command sync failed for {len(failures)} guild(s)", failures)
+    
+    def command_hash(self, guild: Optional[discord.abc.Snowflake] = None) -> str:
+        """Stable hash of the command definitions that would be registered for a guild (or globally)."""
+        payload = [command.to_dict(self.tree) for command in self.tree.get_commands(guild=guild)]
+        payload.sort(key=lambda command: (command.get('type', 1), command['name']))
+        return hashlib.sha256(json.dumps(payload, sort_keys=True).encode('utf-8')).hexdigest()
+    
+    async def sync_commands(self, guild: Optional[discord.abc.Snowflake] = None) -> bool:
+        """
+        Register commands for a guild (or globally), skipping the API call when nothing changed.
+        
+        Returns:
+            bool: True if commands were written, False if the last synced hash matched
+        """
+        scope = str(guild.id) if guild else "global"
+        current_hash = self.command_hash(guild)
+        if self.storage.load_command_hash(scope) == current_hash:
+            print(f"Commands unchanged for {scope}, skipping sync")
+            return False
+        
+        await self.tree.sync(guild=guild)
+        self.storage.save_command_hash(scope, current_hash)
+        return True
+    
+    async def resync_guild(self, guild_id: int) -> Tuple[List[str], List[str], bool]:
+        """
+        Re-register commands for one guild right away, even if the stored hash matches.
+        
+        Returns:
+            (added, removed, changed): Command names added and removed, and whether the definitions changed since the last sync
+        """
+        guild = discord.Object(id=guild_id)
+        before = {command.name for command in await self.tree.fetch_commands(guild=guild)}
+        self.tree.copy_global_to(guild=guild)
+        
+        scope = str(guild_id)
+        current_hash = self.command_hash(guild)
+        changed = self.storage.load_command_hash(scope) != current_hash
+        after = {command.name for command in await self.tree.sync(guild=guild)}
+        self.storage.save_command_hash(scope, current_hash)
+        return sorted(after - before), sorted(before - after), changed
+    
     async def on_ready(self):
         """Called when the bot is ready."""
         print(f'{self.user} has connected to Discord!')
         print(f'Bot is in {len(self.guilds)} guilds')
+        await self.refresh_presence()
+    
+    async def on_guild_remove(self, guild: discord.Guild):
+        """Stop scheduled work for a server the bot was kicked from, or that was deleted."""
+        # Outages fire on_guild_unavailable instead, so this only runs for real removals
+        try:
+            settings = await asyncio.to_thread(self.storage.load_guild_settings, guild.id)
+            if get_bool('PURGE_ON_GUILD_REMOVE'):
+                removed = await asyncio.to_thread(self.storage.purge_guild, guild.id)
+                log(f"Removed from guild {guild.id}; purged {removed} meetings")
+                settings = GuildSettings(guild_id=guild.id)
+                await self.refresh_presence()
+            else:
+                log(f"Removed from guild {guild.id}; marking it inactive")
+            settings.inactive = True
+            await asyncio.to_thread(self.storage.save_guild_settings, settings)
+        except Exception as e:
+            log(f"Warning: Could not deactivate guild {guild.id}: {e}")
+    
+    async def on_guild_join(self, guild: discord.Guild):
+        """Reactivate a server that adds the bot back after removing it."""
+        try:
+            settings = await asyncio.to_thread(self.storage.load_guild_settings, guild.id)
+            if settings.inactive:
+                settings.inactive = False
+                await asyncio.to_thread(self.storage.save_guild_settings, settings)
+                log(f"Rejoined guild {guild.id}; marking it active")
+        except Exception as e:
+            log(f"Warning: Could not reactivate guild {guild.id}: {e}")
+    
+    async def refresh_presence(self):
+        """Show the open meeting count in the bot's presence, throttled to respect rate limits."""
+        if self._presence_task and not self._presence_task.done():
+            # A refresh is already scheduled and will pick up the latest count
+            return
+        
+        wait = self._last_presence_update + PRESENCE_MIN_INTERVAL_SECONDS - time.monotonic()
+        self._presence_task = asyncio.create_task(self._update_presence(max(wait, 0)))
+    
+    async def _update_presence(self, delay: float):
+        """Wait out the throttle window, then push the presence update."""
+        await asyncio.sleep(delay)
+        try:
+            count = await asyncio.to_thread(self.storage.count_open_meetings)
+            template = os.getenv('PRESENCE_TEMPLATE', DEFAULT_PRESENCE_TEMPLATE)
+            activity = discord.Activity(type=discord.ActivityType.watching, name=render_presence(template, count))
+            await self.change_presence(activity=activity)
+            self._last_presence_update = time.monotonic()
+        except Exception as e:
+            print(f"Warning: Could not update presence: {e}")
+    
+    @tasks.loop(time=parse_times(DEFAULT_DIGEST_TIMES))
+    async def post_open_meetings_digest(self):
+        """
+        Post the guild's open meetings to DIGEST_CHANNEL_ID, skipping days with none.
+        
+        With DIGEST_PIN, one pinned message is edited instead, so the channel keeps a stable reference.
+        """
+        if self.schedulers_paused:
+            log("Skipping open meetings digest: schedulers are paused")
+            return
+        try:
+            channel = self.get_channel(self.digest_channel_id) or await self.fetch_channel(self.digest_channel_id)
+            guild_id = channel.guild.id if getattr(channel, 'guild', None) else None
+            if guild_id:
+                settings = await asyncio.to_thread(self.storage.load_guild_settings, guild_id)
+                if settings.disabled or settings.inactive:
+                    return
+            meetings = await asyncio.to_thread(self.storage.find_meetings, guild_id)
+            embed = build_digest_embed(meetings)
+            if get_bool('DIGEST_PIN'):
+                await self._update_pinned_digest(channel, embed or build_empty_digest_embed())
+            elif embed:
+                await channel.send(embed=embed)
+        except Exception as e:
+            log(f"Warning: Could not post open meetings digest: {e}")
+    
+    async def _update_pinned_digest(self, channel: discord.abc.Messageable, embed: discord.Embed):
+        """Edit the channel's pinned digest in place, posting and pinning a new one if it was deleted."""
+        # Shows when the pinned copy was last refreshed
+        embed.timestamp = discord.utils.utcnow()
+        message_id = await asyncio.to_thread(self.storage.load_digest_message_id, channel.id)
+        if message_id:
+            try:
+                await channel.get_partial_message(message_id).edit(embed=embed)
+                return
+            except discord.NotFound:
+                log(f"Pinned digest in channel {channel.id} was deleted; posting a new one")
+        
+        message = await channel.send(embed=embed)
+        await asyncio.to_thread(self.storage.save_digest_message_id, channel.id, message.id)
+        try:
+            await message.pin(reason="Open meetings digest")
+        except discord.HTTPException as e:
+            log(f"Warning: Could not pin digest in channel {channel.id}: {e}")
+    
+    @post_open_meetings_digest.before_loop
+    async def before_open_meetings_digest(self):
+        """Wait for the cache of channels before the first digest."""
+        await self.wait_until_ready()
+    
+    @tasks.loop(minutes=1)
+    async def post_due_announcements(self):
+        """Post scheduled announcements whose time has come; they can run up to a minute late."""
+        if self.schedulers_paused:
+            # Held rather than dropped, so they go out once the owner resumes
+            return
+        try:
+            due = await asyncio.to_thread(self.storage.find_due_announcements, discord.utils.utcnow())
+        except Exception as e:
+            log(f"Warning: Could not load scheduled announcements: {e}")
+            return
+        for announcement in due:
+            await self._post_announcement(announcement)
+    
+    async def _post_announcement(self, announcement: Announcement):
+        """Post one announcement and delete it, keeping it for the next run if Discord had a hiccup."""
+        try:
+            settings = await asyncio.to_thread(self.storage.load_guild_settings, announcement.guild_id)
+            if settings.disabled:
+                # Posted late once the server's administrators turn the bot back on
+                return
+            if not settings.inactive:
+                channel = self.get_channel(announcement.channel_id) or await self.fetch_channel(announcement.channel_id)
+                await channel.send(announcement.message)
+                log(f"Posted announcement {announcement.id} to channel {announcement.channel_id}")
+        except (discord.NotFound, discord.Forbidden) as e:
+            # Retrying can't help once the channel is gone or closed to the bot
+            log(f"Warning: Dropping announcement {announcement.id}: {e}")
+        except Exception as e:
+            log(f"Warning: Could not post announcement {announcement.id}: {e}")
+            return
+        await asyncio.to_thread(self.storage.delete_announcement, announcement.id)
+    
+    @post_due_announcements.before_loop
+    async def before_post_due_announcements(self):
+        """Wait for the cache of channels before posting."""
+        await self.wait_until_ready()
+    
+    async def on_interaction(self, interaction: discord.Interaction):
+        """Answer clicks on buttons whose view no longer exists, e.g. after a restart."""
+        if interaction.type != discord.InteractionType.component:
+            return
+        
+        custom_id = (interaction.data or {}).get('custom_id', '')
+        if is_expired_custom_id(custom_id):
+            log(f"Expired component {custom_id} clicked by {interaction.user}")
+            await reply(interaction, EXPIRED_INTERACTION_MESSAGE, ephemeral=True)
+    
+    async def on_command_error(self, ctx, error):
+        """Handle command errors."""
+        if isinstance(error, commands.CommandNotFound):
+            return
+        
+        log(f"Command error: {error}")
+        await ctx.send(f"An error occurred: {str(error)}")
+
+
+def render_presence(template: str, count: int) -> str:
+    """Render the presence text, falling back to the default on a bad template."""
+    try:
+        return template.format(count=count)
+    except (KeyError, IndexError, ValueError):
+        return DEFAULT_PRESENCE_TEMPLATE.format(count=count)
+
+
+# Create bot instance
+bot = MeetingBot()
+
+
+async def action_autocomplete(interaction: discord.Interaction, current: str) -> List[app_commands.Choice[str]]:
+    """Suggest the invoked command's actions matching what the user has typed."""
+    manager = interaction.command is not None and interaction.command.name == MANAGE_COMMAND_NAME
+    matches = [name for name, action in bot.actions.items() if action.manager == manager and current.lower() in name]
+    return [app_commands.Choice(name=name, value=name) for name in matches[:25]]
+
+
+@bot.tree.command(name="meetingbot", description="Meeting bot commands")
+@app_commands.describe(
+    action="Action to perform",
+    meeting_id="Meeting ID (for update/close/archive/duplicate/announce-again/cohosts/decisions)",
+    days="Share link lifetime (share, max 7) or DM snooze length (dnd), in days",
+    tag="Only show meetings with this tag (for list), or the tag shared by a series (for series-stats)",
+    archived="Show archived meetings instead (for list)",
+    visibility="Who can see the meeting in listings (for new, default public)",
+    user="Member to add (for cohost-add/cohost-remove/roster-set)",
+    text="Decision text (for decision-add)",
+    compact="One line per meeting instead of details; remembered for next time (for list)",
+    anonymous="Hide who wrote each update in summaries and reports (for new)",
+    role="Role expected to post updates (for roster-set)",
+    start="First day, YYYY-MM-DD or as your locale writes dates (for between)",
+    end="Last day, included, in the same format as start (for between)"
+)
+@app_commands.choices(visibility=[
+    app_commands.Choice(name="Public", value=PUBLIC),
+    app_commands.Choice(name="Private", value=PRIVATE),
+])
+@app_commands.autocomplete(action=action_autocomplete)
+async def meetingbot_command(
+    interaction: discord.Interaction,
+    action: str,
+    meeting_id: Optional[str] = None,
+    days: Optional[app_commands.Range[int, 1, 365]] = None,
+    tag: Optional[str] = None,
+    archived: bool = False,
+    visibility: Optional[str] = None,
+    user: Optional[discord.Member] = None,
+    text: Optional[app_commands.Range[str, 1, 1000]] = None,
+    compact: Optional[bool] = None,
+    anonymous: bool = False,
+    role: Optional[discord.Role] = None,
+    start: Optional[str] = None,
+    end: Optional[str] = None
+):
+    """Main slash command handler."""
+    start_request()
+    log(f"/meetingbot {action} invoked by {interaction.user} (meeting: {meeting_id})")
+    
+    options = CommandOptions(meeting_id=meeting_id, days=days, tag=tag, archived=archived, visibility=visibility, user=user, text=text, compact=compact, anonymous=anonymous, role=role, start=start, end=end)
+    await bot.dispatch_action(interaction, action, options)
+
+
+@bot.tree.command(name=MANAGE_COMMAND_NAME, description="Meeting bot commands for managers")
+@app_commands.default_permissions(manage_events=True)
+@app_commands.guild_only()
+@app_commands.describe(
+    action="Action to perform",
+    meeting_id="Meeting ID (for audit/attendance/clear-updates/merge/debug)",
+    days="Days to look back (for analytics, default 30)",
+    text="Message to post (for announce)",
+    at="When to post, YYYY-MM-DD HH:MM in UTC; the date can be written as your locale does (for announce)",
+    channel="Channel to post in (for announce, default this channel)",
+    announcement_id="Scheduled announcement ID (for announce-cancel)",
+    file="Calendar file to import (for import-ics)",
+    role="Role that approves new meetings (for approvals)",
+    target="Meeting ID to merge into (for merge)",
+    field="Update question to make required or optional (for standup-fields)",
+    scope="Change the whole server or just this channel (for standup-fields, default server)"
+)
+@app_commands.choices(field=[
+    app_commands.Choice(name=name.capitalize(), value=name) for name in UPDATE_FIELDS
+], scope=[
+    app_commands.Choice(name="Server", value="server"),
+    app_commands.Choice(name="This channel", value="channel"),
+    app_commands.Choice(name="Reset this channel to the server's settings", value="channel-reset"),
+])
+@app_commands.autocomplete(action=action_autocomplete)
+async def meetingbot_manage_command(
+    interaction: discord.Interaction,
+    action: str,
+    meeting_id: Optional[str] = None,
+    days: Optional[app_commands.Range[int, 1, 365]] = None,
+    text: Optional[app_commands.Range[str, 1, 2000]] = None,
+    at: Optional[str] = None,
+    channel: Optional[discord.TextChannel] = None,
+    announcement_id: Optional[str] = None,
+    file: Optional[discord.Attachment] = None,
+    role: Optional[discord.Role] = None,
+    target: Optional[str] = None,
+    field: Optional[str] = None,
+    scope: Optional[str] = None
+):
+    """Slash command handler for manager actions; handlers still check permissions themselves."""
+    start_request()
+    log(f"/{MANAGE_COMMAND_NAME} {action} invoked by {interaction.user} (meeting: {meeting_id})")
+    
+    options = CommandOptions(meeting_id=meeting_id, days=days, text=text, at=at, channel=channel, announcement_id=announcement_id, file=file, role=role, target=target, field=field, scope=scope)
+    await bot.dispatch_action(interaction, action, options, manager=True)
+
+
+@bot.tree.context_menu(name="Create meeting from message")
+async def create_meeting_from_message(interaction: discord.Interaction, message: discord.Message):
+    """Open the creation modal pre-filled from a message proposing a meeting."""
+    start_request()
+    log(f"Create meeting from message {message.id} invoked by {interaction.user}")
+    if await reject_if_disabled(interaction):
+        return
+    try:
+        first_line = message.content.strip().splitlines()[0] if message.content.strip() else ""
+        url_match = re.search(r'https?://\S+', message.content)
+        modal = CreateMeetingModal(
+            default_name=first_line,
+            default_link=url_match.group(0) if url_match else (bot.default_meeting_link or "")
+        )
+        await interaction.response.send_modal(modal)
+        
+    except Exception as e:
+        log(f"Error creating meeting from message: {e}")
+        await reply(interaction, "❌ Failed to create meeting. Please try again.", ephemeral=True)
+
+
+@bot.tree.context_menu(name="View meetings")
+async def view_member_meetings(interaction: discord.Interaction, member: discord.Member):
+    """Show the meetings a member created or submitted updates to."""
+    start_request()
+    log(f"View meetings for {member} invoked by {interaction.user}")
+    if await reject_if_disabled(interaction):
+        return
+    try:
+        if member.id != interaction.user.id and not is_manager(interaction):
+            await reply(interaction, "❌ You can only view your own meetings unless you have the Manage Events permission.", ephemeral=True)
+            return
+        
+        created, participated = await call_storage(bot.storage.find_user_meetings, str(member))
+        viewer, manager = str(interaction.user), is_manager(interaction)
+        created = [m for m in created if m.guild_id == interaction.guild_id and m.is_visible_to(viewer, manager)]
+        participated = [m for m in participated if m.guild_id == interaction.guild_id and m.is_visible_to(viewer, manager)]
+        
+        def describe(meeting: Meeting) -> str:
+            status = "closed" if meeting.is_closed else "open"
+            return f"`{meeting.id}` {sanitize_for_embed(meeting.name)} ({status})"
+        
+        lines = [f"**Created** · {describe(m)}" for m in created]
+        lines += [f"**Updated** · {describe(m)}" for m in participated]
+        if not lines:
+            await reply(interaction, f"{member.mention} hasn't created or updated any meetings.", ephemeral=True)
+            return
+        
+        view = PaginatedEmbedView(title=f"📅 Meetings for {sanitize_for_embed(member.display_name)}", lines=lines)
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error viewing meetings for {member}: {e}")
+        await reply(interaction, "❌ Failed to load meetings. Please try again.", ephemeral=True)
+
+
+# (permission attribute, label, required) for the bot's features in a channel
+BOT_PERMISSION_CHECKS = [
+    ("send_messages", "Send Messages", True),
+    ("embed_links", "Embed Links", True),
+    ("create_public_threads", "Create Public Threads", False),
+    ("add_reactions", "Add Reactions", False),
+    ("manage_messages", "Manage Messages", False),
+]
+
+
+def interpret_permissions(permissions: discord.Permissions) -> List[Tuple[str, bool, bool]]:
+    """Map the bot's channel permissions to (label, granted, required) entries."""
+    return [
+        (label, getattr(permissions, attribute), required)
+        for attribute, label, required in BOT_PERMISSION_CHECKS
+    ]
+
+
+def is_manager(interaction: discord.Interaction) -> bool:
+    """Check whether the user may use manager-only commands (requires Manage Events)."""
+    return isinstance(interaction.user, discord.Member) and interaction.user.guild_permissions.manage_events
+
+
+def manages_meeting(interaction: discord.Interaction, meeting: Meeting) -> bool:
+    """Check whether the user is a manager in the server the meeting belongs to."""
+    return is_manager(interaction) and meeting.guild_id == interaction.guild_id
+
+
+def can_view_meeting(interaction: discord.Interaction, meeting: Meeting) -> bool:
+    """Check whether a meeting belongs to this server and the user may see it if it's private."""
+    return meeting.guild_id == interaction.guild_id and meeting.is_visible_to(str(interaction.user), manages_meeting(interaction, meeting))
+
+
+def is_admin(interaction: discord.Interaction) -> bool:
+    """Check whether the user is a server administrator."""
+    return isinstance(interaction.user, discord.Member) and interaction.user.guild_permissions.administrator
+
+
+async def is_guild_disabled(guild_id: Optional[int]) -> bool:
+    """Check whether a server's administrators have put the bot into maintenance mode."""
+    if not guild_id:
+        return False
+    settings = await call_storage(bot.storage.load_guild_settings, guild_id)
+    return settings.disabled
+
+
+async def reject_if_disabled(interaction: discord.Interaction) -> bool:
+    """Answer non-administrators in a disabled server, returning True if the command should stop."""
+    if is_admin(interaction):
+        return False
+    try:
+        disabled = await is_guild_disabled(interaction.guild_id)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+        return True
+    if disabled:
+        await reply(interaction, DISABLED_MESSAGE, ephemeral=True)
+    return disabled
+
+
+async def call_storage(func, *args):
+    """Run a blocking storage call off the event loop, failing fast if storage is unavailable."""
+    try:
+        return await asyncio.wait_for(asyncio.to_thread(func, *args), timeout=STORAGE_TIMEOUT_SECONDS)
+    except (OSError, asyncio.TimeoutError) as e:
+        bot.storage_error_count += 1
+        log(f"Storage call {func.__name__} failed ({bot.storage_error_count} storage errors so far): {e!r}")
+        raise StorageUnavailableError(str(e)) from e
+
+
+async def get_card_message(meeting: Meeting) -> Optional[discord.PartialMessage]:
+    """Get a handle to the meeting's card message, if it was recorded."""
+    if not (meeting.channel_id and meeting.message_id):
+        return None
+    channel = bot.get_channel(meeting.channel_id) or await bot.fetch_channel(meeting.channel_id)
+    return channel.get_partial_message(meeting.message_id)
+
+
+async def get_meeting_thread(meeting: Meeting, name: str) -> Optional[discord.Thread]:
+    """
+    Get the meeting's thread, starting one on its card if it doesn't have one yet.
+    
+    Sets meeting.thread_id when a thread is created; callers decide whether to save it.
+    
+    Returns:
+        The thread, or None if the meeting has no card to start one from
+    """
+    if meeting.thread_id:
+        try:
+            return bot.get_channel(meeting.thread_id) or await bot.fetch_channel(meeting.thread_id)
+        except discord.NotFound:
+            # Thread was deleted; start a new one
+            meeting.thread_id = None
+    
+    card = await get_card_message(meeting)
+    if not card:
+        return None
+    thread = await card.create_thread(name=name[:100])
+    meeting.thread_id = thread.id
+    return thread
+
+
+async def post_update_to_thread(meeting: Meeting, update: Update, replaced: bool):
+    """
+    Post a submitted update to the meeting's thread so it doubles as a live standup board.
+    
+    Enabled with POST_UPDATES_TO_THREAD; falls back to the card's channel when no thread can be made.
+    """
+    if not get_bool('POST_UPDATES_TO_THREAD'):
+        return
+    
+    embed = discord.Embed(
+        title=f"📝 {meeting.update_author(update)}" + (" (edited)" if replaced else ""),
+        color=0x0099ff,
+        timestamp=datetime.fromisoformat(update.timestamp)
+    )
+    embed.add_field(name="Progress", value=sanitize_for_embed(update.progress[:900]) or "—", inline=False)
+    embed.add_field(name="Blockers", value=sanitize_for_embed(update.blockers[:900]) or "—", inline=False)
+    embed.add_field(name="Goals", value=sanitize_for_embed(update.goals[:900]) or "—", inline=False)
+    
+    thread_id = meeting.thread_id
+    try:
+        thread = await get_meeting_thread(meeting, f"{meeting.name} updates")
+        if thread:
+            await thread.send(embed=embed)
+        elif meeting.channel_id:
+            channel = bot.get_channel(meeting.channel_id) or await bot.fetch_channel(meeting.channel_id)
+            await channel.send(embed=embed)
+    except discord.HTTPException as e:
+        log(f"Warning: Could not post update to thread for meeting {meeting.id}: {e}")
+        return
+    
+    if meeting.thread_id != thread_id:
+        # Reload so a concurrent update isn't overwritten when recording the new thread
+        try:
+            latest = await call_storage(bot.storage.get_meeting, meeting.id)
+            latest.thread_id = meeting.thread_id
+            await call_storage(bot.storage.save_meeting, latest)
+        except Exception as e:
+            log(f"Warning: Could not save thread for meeting {meeting.id}: {e}")
+
+
+async def reply(interaction: discord.Interaction, content: Optional[str] = None, **kwargs):
+    """
+    Respond to an interaction, sending a followup instead if it was already acknowledged.
+    
+    Covers a slow first response that Discord still counted, which would otherwise
+    fail with "interaction already acknowledged" on the next attempt.
+    """
+    if content is not None:
+        kwargs['content'] = content
+    
+    if not interaction.response.is_done():
+        try:
+            await interaction.response.send_message(**kwargs)
+            return
+        except discord.InteractionResponded:
+            log("Interaction was already acknowledged; replying with a followup")
+        except discord.HTTPException as e:
+            # is_done() only knows about this process; Discord may have counted a response that timed out here
+            if e.code != INTERACTION_ALREADY_ACKNOWLEDGED:
+                raise
+            log("Interaction was already acknowledged; replying with a followup")
+    
+    await interaction.followup.send(**kwargs)
+
+
+async def send_followup(interaction: discord.Interaction, ephemeral: bool = False, **kwargs) -> Optional[discord.WebhookMessage]:
+    """
+    Send an additional message after the interaction's initial response.
+    
+    Keyword arguments left as None (e.g. an optional view) are omitted.
+    
+    Returns:
+        The sent message, or None if sending failed
+    """
+    kwargs = {key: value for key, value in kwargs.items() if value is not None}
+    try:
+        return await interaction.followup.send(ephemeral=ephemeral, wait=True, **kwargs)
+    except discord.HTTPException as e:
+        log(f"Warning: Could not send followup message: {e}")
+        return None
+
+
+async def record_audit(meeting_id: str, event_type: str, interaction: discord.Interaction, anonymous: bool = False):
+    """
+    Append an audit event for a meeting without interrupting the calling handler.
+    
+    With `anonymous`, the actor isn't recorded, so the log can't unmask anonymous updates.
+    """
+    try:
+        actor = ANONYMOUS_AUTHOR if anonymous else str(interaction.user)
+        event = AuditEvent.create_new(event_type=event_type, actor=actor, meeting_id=meeting_id)
+        await call_storage(bot.storage.append_audit_event, event)
+    except Exception as e:
+        log(f"Warning: Could not record audit event '{event_type}' for meeting {meeting_id}: {e}")
+
+
+@bot.register_action("new")
+async def handle_new_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle creating a new meeting."""
+    try:
+        modal = CreateMeetingModal(
+            default_link=bot.default_meeting_link or "",
+            visibility=options.visibility or PUBLIC,
+            anonymous_updates=options.anonymous
+        )
+        await interaction.response.send_modal(modal)
+
+    except Exception as e:
+        log(f"Error creating meeting: {e}")
+        await reply(interaction, "❌ Failed to create meeting. Please try again.", ephemeral=True)
+
+
+async def load_optional_update_fields(guild_id: Optional[int], channel_id: Optional[int] = None) -> List[str]:
+    """
+    Get the update questions made optional for a meeting's channel.
+    
+    A channel's own setting wins, then the server's; outside servers every question is required.
+    """
+    if not guild_id:
+        return []
+    if channel_id:
+        channel_settings = await call_storage(bot.storage.load_channel_settings, channel_id)
+        if channel_settings.optional_update_fields is not None:
+            return channel_settings.optional_update_fields
+    settings = await call_storage(bot.storage.load_guild_settings, guild_id)
+    return settings.optional_update_fields
+
+
+@bot.register_action("update", requires_meeting_id=True)
+async def handle_update_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle updating a meeting with a modal form."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_open_meeting, meeting_id)
+
+        # Resubmitting on the same day edits the earlier update instead of adding another
+        existing = meeting.find_update(str(interaction.user), datetime.now().date())
+        optional_fields = await load_optional_update_fields(meeting.guild_id or interaction.guild_id, meeting.channel_id or interaction.channel_id)
+        
+        # First-time users get a short tips embed before the form
+        prefs = await call_storage(bot.storage.load_user_prefs, interaction.user.id)
+        if not prefs.seen_update_tips:
+            prefs.seen_update_tips = True
+            await call_storage(bot.storage.save_user_prefs, prefs)
+            view = UpdateTipsView(meeting_id, optional_fields)
+            await reply(interaction, embed=build_update_tips_embed(), view=view, ephemeral=True)
+            return
+        
+        modal = UpdateModal(meeting_id, existing, optional_fields)
+        await interaction.response.send_modal(modal)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except MeetingClosedError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` is no longer open and cannot be updated.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error handling update: {e}")
+        await reply(interaction, "❌ Failed to process update request. Please try again.", ephemeral=True)
+
+
+@bot.register_action("close", requires_meeting_id=True)
+async def handle_close_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle closing a meeting."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        
+        if meeting.is_closed:
+            await reply(interaction, f"❌ Meeting `{meeting_id}` is already closed.", ephemeral=True)
+            return
+        
+        if not meeting.is_host(str(interaction.user)):
+            await reply(interaction, f"❌ Only the meeting's creator or co-hosts can do that.", ephemeral=True)
+            return
+
+        meeting.close()
+        await call_storage(bot.storage.save_meeting, meeting)
+        await record_audit(meeting_id, "close", interaction)
+        await bot.refresh_presence()
+        await refresh_meeting_card(meeting)
+        
+        if get_bool('PIN_MEETING_CARDS'):
+            try:
+                card = await get_card_message(meeting)
+                if card:
+                    await card.unpin(reason=f"Meeting {meeting_id} closed")
+            except discord.HTTPException as e:
+                log(f"Warning: Could not unpin card for meeting {meeting_id}: {e}")
+        
+        # Upload to S3 (silent operation)
+        presigned_url = None
+        if bot.s3_storage and bot.s3_storage.is_available():
+            try:
+                # Generate HTML report
+                html_content = bot.report_generator.generate_html_report(meeting)
+                if html_content:
+                    bot.s3_storage.upload_meeting_json(meeting_id, meeting.to_dict())
+                    bot.s3_storage.upload_html_report(meeting_id, html_content)
+                else:
+                    log(f"Warning: Could not generate HTML report for meeting {meeting_id}")
+                
+                presigned_url = bot.s3_storage.generate_presigned_url(meeting_id)
+            except Exception as e:
+                log(f"Warning: S3 upload failed for meeting {meeting_id}: {e}")
+                # Continue with Discord response even if S3 fails
+        else:
+            log(f"S3 not available, skipping upload for meeting {meeting_id}")
+        
+        presigned_url = presigned_url if presigned_url else "Automatic presigned url unavailable"
+        
+        embed = build_close_summary(meeting, interaction, presigned_url)
+        await deliver_close_summary(interaction, meeting, embed)
+        
+        # The summary is already posted, so a storage failure here only skips the DM
+        try:
+            prefs = await call_storage(bot.storage.load_user_prefs, interaction.user.id)
+        except StorageUnavailableError:
+            log(f"Warning: Could not check DM preference for meeting {meeting_id}")
+        else:
+            if prefs.dm_close_summary:
+                await send_minutes_dm(interaction, meeting)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error closing meeting: {e}")
+        await reply(interaction, "❌ Failed to close meeting. Please try again.", ephemeral=True)
+
+
+def build_close_summary(meeting: Meeting, interaction: discord.Interaction, presigned_url: str) -> discord.Embed:
+    """Build the summary embed sent when a meeting is closed."""
+    embed = discord.Embed(
+        title="🔒 Meeting Closed",
+        description=f"Meeting `{meeting.id}` has been closed.",
+        color=0xff6b6b
+    )
+
+    embed.add_field(name="Total Updates", value=str(len(meeting.updates)), inline=True)
+    embed.add_field(name="Closed by", value=interaction.user.mention, inline=True)
+    embed.add_field(name="Closed at", value=f"<t:{int(interaction.created_at.timestamp())}:F>", inline=True)
+
+    embed.add_field(name="View meeting report at presigned url:", value=presigned_url, inline=False)
+    
+    link = sanitize_for_embed(meeting.link) if meeting.link else "This meeting has no link."
+    embed.add_field(name="Join meeting at link:", value=link, inline=False)
+    
+    if meeting.location:
+        embed.add_field(name="Location:", value=sanitize_for_embed(meeting.location), inline=False)
+    
+    if meeting.tags:
+        embed.add_field(name="Tags:", value=sanitize_list(meeting.tags), inline=False)
+    
+    if meeting.co_hosts:
+        embed.add_field(name="Co-hosts:", value=sanitize_list(meeting.co_hosts), inline=False)
+    
+    if meeting.decisions:
+        decisions = "\n".join(f"• {sanitize_for_embed(decision.text)}" for decision in meeting.decisions)
+        embed.add_field(name="Decisions:", value=decisions[:1024], inline=False)
+    
+    if meeting.jump_url:
+        embed.add_field(name="Original meeting post:", value=meeting.jump_url, inline=False)
+    
+    embed.set_footer(text="Meeting data has been saved and locked.")
+    return embed
+
+
+async def find_member(guild: discord.Guild, name: str, user_id: Optional[int]) -> Optional[discord.Member]:
+    """
+    Find a member by their stored ID, or by name for meetings saved before IDs were recorded.
+    
+    Returns:
+        The member, or None if they've left the server
+    """
+    if user_id is None:
+        return guild.get_member_named(name)
+    member = guild.get_member(user_id)
+    if member:
+        return member
+    try:
+        return await guild.fetch_member(user_id)
+    except discord.NotFound:
+        return None
+
+
+async def resolve_participants(interaction: discord.Interaction, meeting: Meeting) -> List[discord.abc.User]:
+    """Find the members behind a meeting's hosts and updaters, plus the closing user."""
+    names = dict.fromkeys([meeting.created_by, *meeting.co_hosts, *(update.user for update in meeting.updates)])
+    users = {interaction.user.id: interaction.user}
+    if interaction.guild:
+        for name in names:
+            member = await find_member(interaction.guild, name, meeting.user_ids.get(name))
+            if member:
+                users.setdefault(member.id, member)
+    return list(users.values())
+
+
+async def deliver_close_summary(interaction: discord.Interaction, meeting: Meeting, embed: discord.Embed):
+    """Send the close summary to the channel, a thread on the meeting card, participants' DMs, or nowhere."""
+    destination = get_choice('SUMMARY_DESTINATION', SUMMARY_DESTINATIONS, "channel")
+    rating_view = build_rating_view(meeting.id)
+    if destination == "channel":
+        await reply(interaction, embed=embed, view=rating_view)
+        return
+    
+    await reply(interaction, f"🔒 Meeting `{meeting.id}` has been closed.", ephemeral=True)
+    
+    if destination == "thread":
+        try:
+            # Reuses the live updates thread when there is one
+            thread = await get_meeting_thread(meeting, f"{meeting.name} summary")
+            if thread:
+                await thread.send(embed=embed, view=rating_view)
+                return
+        except discord.HTTPException as e:
+            log(f"Warning: Could not post summary thread for meeting {meeting.id}: {e}")
+        # Fall back to the channel when there's no card to start a thread from
+        await send_followup(interaction, embed=embed, view=rating_view)
+    
+    elif destination == "dm":
+        # The closer is clearly around, so only the other participants' snoozes apply
+        recipients, snoozed = await without_dnd_users(await resolve_participants(interaction, meeting), keep=interaction.user)
+        failures = await send_batch_dms(recipients, lambda user: {'embed': embed})
+        if failures:
+            failed = sanitize_list(str(user) for user, _ in failures)
+            await send_followup(interaction, ephemeral=True, content=f"⚠️ Couldn't DM the summary to: {failed}")
+        if snoozed:
+            await send_followup(interaction, ephemeral=True, content=f"🔕 Didn't DM {sanitize_list(str(user) for user in snoozed)}; they snoozed DMs.")
+
+
+async def without_dnd_users(
+    users: List[discord.abc.User],
+    keep: Optional[discord.abc.User] = None
+) -> Tuple[List[discord.abc.User], List[discord.abc.User]]:
+    """
+    Split DM recipients by whether they have do-not-disturb on; `keep` is always sent to.
+    
+    A storage failure leaves everyone in, since missing a DM is worse than an unwanted one.
+    
+    Returns:
+        (recipients, snoozed)
+    """
+    recipients, snoozed = [], []
+    for user in users:
+        try:
+            prefs = await call_storage(bot.storage.load_user_prefs, user.id)
+        except StorageUnavailableError:
+            prefs = None
+        if prefs and prefs.dnd_remaining() and not (keep and user.id == keep.id):
+            snoozed.append(user)
+        else:
+            recipients.append(user)
+    return recipients, snoozed
+
+
+async def send_minutes_dm(interaction: discord.Interaction, meeting: Meeting):
+    """DM the closing user the meeting's Markdown minutes, telling them privately if DMs are off."""
+    minutes = bot.report_generator.generate_markdown_minutes(meeting).encode('utf-8')
+    
+    def build_message(user: discord.abc.User) -> dict:
+        return {
+            'content': f"📝 Minutes for **{sanitize_for_embed(meeting.name)}** (`{meeting.id}`)",
+            'file': discord.File(io.BytesIO(minutes), filename=f"{meeting.id}-minutes.md")
+        }
+    
+    for _, error in await send_batch_dms([interaction.user], build_message):
+        if isinstance(error, discord.Forbidden):
+            await send_followup(interaction, ephemeral=True, content="⚠️ Couldn't DM you the meeting minutes. Check that you allow direct messages from server members.")
+        else:
+            log(f"Warning: Could not DM minutes for meeting {meeting.id}: {error}")
+
+
+@bot.register_action("dm-summary")
+async def handle_toggle_dm_summary(interaction: discord.Interaction, options: CommandOptions):
+    """Handle toggling whether closing a meeting DMs the user its minutes."""
+    try:
+        prefs = await call_storage(bot.storage.load_user_prefs, interaction.user.id)
+        prefs.dm_close_summary = not prefs.dm_close_summary
+        await call_storage(bot.storage.save_user_prefs, prefs)
+        
+        if prefs.dm_close_summary:
+            message = "📬 You'll now get the minutes by DM when you close a meeting."
+        else:
+            message = "📭 You'll no longer get the minutes by DM when you close a meeting."
+        await reply(interaction, message, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error toggling DM summary: {e}")
+        await reply(interaction, "❌ Failed to update your preference. Please try again.", ephemeral=True)
+
+
+def format_duration(delta: timedelta) -> str:
+    """Render a positive duration as days and hours, e.g. "2d 5h"."""
+    hours = int(delta.total_seconds() // 3600)
+    days, hours = divmod(hours, 24)
+    if days:
+        return f"{days}d {hours}h" if hours else f"{days}d"
+    return f"{hours}h" if hours else "less than an hour"
+
+
+@bot.register_action("dnd")
+async def handle_dnd(interaction: discord.Interaction, options: CommandOptions):
+    """Handle snoozing notification DMs for a number of days, or showing the current snooze."""
+    try:
+        prefs = await call_storage(bot.storage.load_user_prefs, interaction.user.id)
+        
+        if options.days:
+            until = datetime.now() + timedelta(days=options.days)
+            prefs.dnd_until = until.isoformat()
+            await call_storage(bot.storage.save_user_prefs, prefs)
+            message = (
+                f"🔕 Notification DMs are snoozed until <t:{int(until.timestamp())}:f>. "
+                "Use `/meetingbot dnd-off` to turn them back on sooner."
+            )
+        else:
+            remaining = prefs.dnd_remaining()
+            if remaining:
+                message = (
+                    f"🔕 Notification DMs are snoozed for another {format_duration(remaining)}. "
+                    "Use `/meetingbot dnd-off` to turn them back on."
+                )
+            else:
+                message = "🔔 Notification DMs are on. Pass `days` to snooze them, e.g. while you're on leave."
+        await reply(interaction, message, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error changing do-not-disturb: {e}")
+        await reply(interaction, "❌ Failed to update your preference. Please try again.", ephemeral=True)
+
+
+@bot.register_action("dnd-off")
+async def handle_dnd_off(interaction: discord.Interaction, options: CommandOptions):
+    """Handle ending a DM snooze early."""
+    try:
+        prefs = await call_storage(bot.storage.load_user_prefs, interaction.user.id)
+        if not prefs.dnd_remaining():
+            await reply(interaction, "🔔 Notification DMs are already on.", ephemeral=True)
+            return
+        
+        prefs.dnd_until = None
+        await call_storage(bot.storage.save_user_prefs, prefs)
+        await reply(interaction, "🔔 Notification DMs are back on.", ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error changing do-not-disturb: {e}")
+        await reply(interaction, "❌ Failed to update your preference. Please try again.", ephemeral=True)
+
+
+async def change_co_hosts(interaction: discord.Interaction, options: CommandOptions, add: bool):
+    """Add or remove a co-host. Only the meeting's creator may change the co-host list."""
+    meeting_id = options.meeting_id
+    try:
+        if not options.user:
+            await reply(interaction, "❌ Pick a member with the `user` option.", ephemeral=True)
+            return
+        
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        
+        if str(interaction.user) != meeting.created_by:
+            await reply(interaction, "❌ Only the meeting's creator can change its co-hosts.", ephemeral=True)
+            return
+        
+        co_host = str(options.user)
+        if add:
+            if meeting.is_host(co_host):
+                await reply(interaction, f"❌ {options.user.mention} already hosts meeting `{meeting_id}`.", ephemeral=True)
+                return
+            meeting.co_hosts.append(co_host)
+            meeting.remember_user(co_host, options.user.id)
+        else:
+            if co_host not in meeting.co_hosts:
+                await reply(interaction, f"❌ {options.user.mention} is not a co-host of meeting `{meeting_id}`.", ephemeral=True)
+                return
+            meeting.co_hosts.remove(co_host)
+        
+        await call_storage(bot.storage.save_meeting, meeting)
+        await record_audit(meeting_id, "cohost-add" if add else "cohost-remove", interaction)
+        
+        verb = "is now a co-host of" if add else "is no longer a co-host of"
+        await reply(interaction, f"✅ {options.user.mention} {verb} meeting `{meeting_id}`.", ephemeral=True)
+        await refresh_meeting_card(meeting)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error changing co-hosts: {e}")
+        await reply(interaction, "❌ Failed to change co-hosts. Please try again.", ephemeral=True)
+
+
+@bot.register_action("cohost-add", requires_meeting_id=True)
+async def handle_add_co_host(interaction: discord.Interaction, options: CommandOptions):
+    """Handle giving a member the same management rights as the meeting's creator."""
+    await change_co_hosts(interaction, options, add=True)
+
+
+@bot.register_action("cohost-remove", requires_meeting_id=True)
+async def handle_remove_co_host(interaction: discord.Interaction, options: CommandOptions):
+    """Handle removing a meeting co-host."""
+    await change_co_hosts(interaction, options, add=False)
+
+
+async def load_roster_meeting(interaction: discord.Interaction, meeting_id: str) -> Optional[Meeting]:
+    """Load a meeting whose roster the user may manage (hosts and managers), replying if they can't."""
+    meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+    if not (meeting.is_host(str(interaction.user)) or manages_meeting(interaction, meeting)):
+        await reply(interaction, "❌ Only the meeting's hosts and managers can manage its roster.", ephemeral=True)
+        return None
+    return meeting
+
+
+@bot.register_action("roster-set", requires_meeting_id=True)
+async def handle_roster_set(interaction: discord.Interaction, options: CommandOptions):
+    """Handle setting a meeting's expected participants from a role, or adding a single member."""
+    meeting_id = options.meeting_id
+    try:
+        if not (options.role or options.user):
+            await reply(interaction, "❌ Pick a `role` to use as the roster, or a `user` to add to it.", ephemeral=True)
+            return
+        
+        meeting = await load_roster_meeting(interaction, meeting_id)
+        if not meeting:
+            return
+        
+        if options.role:
+            # Relies on the members intent; without it the cache only holds members seen recently
+            members = [member for member in options.role.members if not member.bot]
+            if not members:
+                await reply(interaction, f"❌ Couldn't find any members in {options.role.mention}.", ephemeral=True)
+                return
+            meeting.roster = [str(member) for member in members]
+            for member in members:
+                meeting.remember_user(str(member), member.id)
+        if options.user and str(options.user) not in meeting.roster:
+            meeting.roster.append(str(options.user))
+            meeting.remember_user(str(options.user), options.user.id)
+        
+        await call_storage(bot.storage.save_meeting, meeting)
+        await record_audit(meeting_id, "roster-set", interaction)
+        await reply(interaction, f"✅ Meeting `{meeting_id}` now expects {len(meeting.roster)} participant(s).", ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error setting roster: {e}")
+        await reply(interaction, "❌ Failed to set the roster. Please try again.", ephemeral=True)
+
+
+@bot.register_action("roster-clear", requires_meeting_id=True)
+async def handle_roster_clear(interaction: discord.Interaction, options: CommandOptions):
+    """Handle removing every expected participant from a meeting."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await load_roster_meeting(interaction, meeting_id)
+        if not meeting:
+            return
+        
+        meeting.roster = []
+        await call_storage(bot.storage.save_meeting, meeting)
+        await record_audit(meeting_id, "roster-clear", interaction)
+        await reply(interaction, f"✅ Cleared the roster for meeting `{meeting_id}`.", ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error clearing roster: {e}")
+        await reply(interaction, "❌ Failed to clear the roster. Please try again.", ephemeral=True)
+
+
+@bot.register_action("roster-show", requires_meeting_id=True)
+async def handle_roster_show(interaction: discord.Interaction, options: CommandOptions):
+    """Handle showing which expected participants have and haven't submitted an update."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        if not can_view_meeting(interaction, meeting):
+            await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+            return
+        
+        if not meeting.roster:
+            await reply(interaction, f"Meeting `{meeting_id}` has no roster. Set one with `/meetingbot roster-set`.", ephemeral=True)
+            return
+        
+        submitted, missing = meeting.roster_status()
+        lines = [f"✅ {sanitize_for_embed(user)}" for user in submitted] + [f"⏳ {sanitize_for_embed(user)}" for user in missing]
+        view = PaginatedEmbedView(
+            title=f"👥 Roster for {sanitize_for_embed(meeting.name)} ({len(submitted)}/{len(meeting.roster)} submitted)",
+            lines=lines,
+            per_page=20
+        )
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error showing roster: {e}")
+        await reply(interaction, "❌ Failed to load the roster. Please try again.", ephemeral=True)
+
+
+def collect_missing_updates(meetings: List[Meeting]) -> Dict[str, List[Meeting]]:
+    """Map each rostered member who hasn't posted to the open meetings they're missing."""
+    missing_by_user: Dict[str, List[Meeting]] = {}
+    for meeting in meetings:
+        if meeting.is_closed or meeting.is_archived or meeting.is_pending:
+            continue
+        _, missing = meeting.roster_status()
+        for user in missing:
+            missing_by_user.setdefault(user, []).append(meeting)
+    return missing_by_user
+
+
+def build_reminder_message(meetings: List[Meeting]) -> str:
+    """DM text nudging a member to post updates to the given meetings."""
+    lines = [f"⏰ Reminder: you haven't posted an update to {len(meetings)} open meeting(s) yet."]
+    for meeting in meetings:
+        where = f" ({meeting.jump_url})" if meeting.jump_url else ""
+        lines.append(f"• **{sanitize_for_embed(meeting.name)}**: `/meetingbot update meeting_id:{meeting.id}`{where}")
+    return "\n".join(lines)
+
+
+@bot.register_action("remind-all", manager=True)
+async def handle_remind_all(interaction: discord.Interaction, options: CommandOptions):
+    """Handle asking a manager to confirm DMing everyone missing from open meetings' rosters."""
+    try:
+        if not interaction.guild:
+            await reply(interaction, "❌ Reminders can only be sent from a server.", ephemeral=True)
+            return
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to send reminders.", ephemeral=True)
+            return
+        
+        meetings = await call_storage(bot.storage.find_meetings, interaction.guild.id)
+        missing_by_user = collect_missing_updates(meetings)
+        if not missing_by_user:
+            await reply(interaction, "Everyone on an open meeting's roster has posted an update. Nobody to remind.", ephemeral=True)
+            return
+        
+        meeting_count = len({meeting.id for pending in missing_by_user.values() for meeting in pending})
+        await reply(
+            interaction,
+            f"⚠️ DM {len(missing_by_user)} member(s) who haven't posted to {meeting_count} open meeting(s)?",
+            view=RemindAllView(),
+            ephemeral=True
+        )
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error preparing reminders: {e}")
+        await reply(interaction, "❌ Failed to prepare reminders. Please try again.", ephemeral=True)
+
+
+@bot.register_action("decision-add", requires_meeting_id=True)
+async def handle_add_decision(interaction: discord.Interaction, options: CommandOptions):
+    """Handle recording a decision made in a meeting."""
+    meeting_id = options.meeting_id
+    try:
+        if not options.text:
+            await reply(interaction, "❌ Write the decision in the `text` option.", ephemeral=True)
+            return
+        
+        meeting = await call_storage(bot.storage.get_open_meeting, meeting_id)
+        
+        # Hosts, anyone who submitted an update, and managers may record decisions
+        user_str = str(interaction.user)
+        participated = any(update.user == user_str for update in meeting.updates)
+        if not (meeting.is_host(user_str) or participated or is_manager(interaction)):
+            await reply(interaction, "❌ Only participants and managers can record decisions for this meeting.", ephemeral=True)
+            return
+        
+        meeting.add_decision(options.text, user_str)
+        await call_storage(bot.storage.save_meeting, meeting)
+        await record_audit(meeting_id, "decision", interaction)
+        
+        await reply(interaction, f"✅ Decision recorded for meeting `{meeting_id}`:\n> {sanitize_for_embed(options.text.strip())}", ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except MeetingClosedError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` is no longer open.", ephemeral=True)
+    except ValueError as e:
+        await reply(interaction, f"❌ {str(e)}", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error recording decision: {e}")
+        await reply(interaction, "❌ Failed to record decision. Please try again.", ephemeral=True)
+
+
+@bot.register_action("archive", requires_meeting_id=True)
+async def handle_archive_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle quietly archiving a meeting without posting a summary."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        
+        if meeting.is_archived:
+            await reply(interaction, f"❌ Meeting `{meeting_id}` is already archived.", ephemeral=True)
+            return
+        
+        if not meeting.is_host(str(interaction.user)):
+            await reply(interaction, f"❌ Only the meeting's creator or co-hosts can do that.", ephemeral=True)
+            return
+        
+        meeting.archive()
+        await call_storage(bot.storage.save_meeting, meeting)
+        await record_audit(meeting_id, "archive", interaction)
+        await bot.refresh_presence()
+        await refresh_meeting_card(meeting)
+        
+        if get_bool('PIN_MEETING_CARDS'):
+            try:
+                card = await get_card_message(meeting)
+                if card:
+                    await card.unpin(reason=f"Meeting {meeting_id} archived")
+            except discord.HTTPException as e:
+                log(f"Warning: Could not unpin card for meeting {meeting_id}: {e}")
+        
+        await reply(
+            interaction,
+            f"🗄️ Meeting `{meeting_id}` has been archived. Use `/meetingbot list archived:True` to find it again.",
+            ephemeral=True
+        )
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error archiving meeting: {e}")
+        await reply(interaction, "❌ Failed to archive meeting. Please try again.", ephemeral=True)
+
+
+@bot.register_action("clear-updates", requires_meeting_id=True, manager=True)
+async def handle_clear_updates(interaction: discord.Interaction, options: CommandOptions):
+    """Handle asking a manager to confirm deleting all of a meeting's updates."""
+    meeting_id = options.meeting_id
+    try:
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to clear updates.", ephemeral=True)
+            return
+        
+        meeting = await call_storage(bot.storage.get_open_meeting, meeting_id)
+        # Treat other servers' meetings as missing rather than confirming they exist
+        if meeting.guild_id != interaction.guild_id:
+            raise MeetingNotFoundError(meeting_id)
+        if not meeting.updates:
+            await reply(interaction, f"Meeting `{meeting_id}` has no updates to clear.", ephemeral=True)
+            return
+        
+        await reply(
+            interaction,
+            f"⚠️ Delete all {len(meeting.updates)} updates from **{sanitize_for_embed(meeting.name)}** (`{meeting_id}`)? "
+            "The meeting stays open so everyone can resubmit. This can't be undone.",
+            view=ClearUpdatesView(meeting_id),
+            ephemeral=True
+        )
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except MeetingClosedError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` is closed.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error preparing to clear updates: {e}")
+        await reply(interaction, "❌ Failed to clear updates. Please try again.", ephemeral=True)
+
+
+@bot.register_action("merge", requires_meeting_id=True, manager=True)
+async def handle_merge_meetings(interaction: discord.Interaction, options: CommandOptions):
+    """Handle asking a manager to confirm merging a duplicate meeting into another."""
+    source_id, target_id = options.meeting_id, options.target
+    try:
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to merge meetings.", ephemeral=True)
+            return
+        if not target_id:
+            await reply(interaction, "❌ Give the meeting to merge into with `target`.", ephemeral=True)
+            return
+        if source_id == target_id:
+            await reply(interaction, "❌ A meeting can't be merged into itself.", ephemeral=True)
+            return
+        
+        source = await call_storage(bot.storage.get_meeting, source_id)
+        target = await call_storage(bot.storage.get_open_meeting, target_id)
+        if source.guild_id != interaction.guild_id or target.guild_id != interaction.guild_id:
+            await reply(interaction, "❌ Both meetings must belong to this server.", ephemeral=True)
+            return
+        
+        await reply(
+            interaction,
+            f"⚠️ Move {len(source.updates)} updates and {len(source.decisions)} decisions from "
+            f"**{sanitize_for_embed(source.name)}** (`{source_id}`) into **{sanitize_for_embed(target.name)}** (`{target_id}`), then delete `{source_id}`? "
+            "This can't be undone.",
+            view=MergeMeetingsView(source_id, target_id),
+            ephemeral=True
+        )
+        
+    except MeetingNotFoundError as e:
+        await reply(interaction, f"❌ Meeting `{e.args[0]}` not found.", ephemeral=True)
+    except MeetingClosedError:
+        await reply(interaction, f"❌ Meeting `{target_id}` is closed; merge into an open meeting.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error preparing to merge meetings: {e}")
+        await reply(interaction, "❌ Failed to merge meetings. Please try again.", ephemeral=True)
+
+
+@bot.register_action("standup-fields", manager=True)
+async def handle_standup_fields(interaction: discord.Interaction, options: CommandOptions):
+    """Handle showing or toggling which update questions this server, or this channel, requires."""
+    scope = options.scope or "server"
+    try:
+        if not interaction.guild_id:
+            await reply(interaction, "❌ Update questions can only be configured in a server.", ephemeral=True)
+            return
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to change update questions.", ephemeral=True)
+            return
+        
+        guild_settings = await call_storage(bot.storage.load_guild_settings, interaction.guild_id)
+        channel_settings = await call_storage(bot.storage.load_channel_settings, interaction.channel_id)
+        
+        if scope == "channel-reset":
+            channel_settings.optional_update_fields = None
+            await call_storage(bot.storage.save_channel_settings, channel_settings)
+        elif options.field and scope == "channel":
+            current = channel_settings.optional_update_fields
+            channel_settings.optional_update_fields = toggle_optional_field(
+                guild_settings.optional_update_fields if current is None else current, options.field
+            )
+            await call_storage(bot.storage.save_channel_settings, channel_settings)
+        elif options.field:
+            guild_settings.optional_update_fields = toggle_optional_field(guild_settings.optional_update_fields, options.field)
+            await call_storage(bot.storage.save_guild_settings, guild_settings)
+        
+        if options.field or scope == "channel-reset":
+            log(
+                f"{interaction.user} changed update questions ({scope}) in guild {interaction.guild_id}: "
+                f"server={guild_settings.optional_update_fields} channel={channel_settings.optional_update_fields}"
+            )
+        
+        overridden = channel_settings.optional_update_fields is not None
+        optional = channel_settings.optional_update_fields if overridden else guild_settings.optional_update_fields
+        lines = [
+            f"{'➖ Optional' if name in optional else '✅ Required'}: **{name.capitalize()}**"
+            for name in UPDATE_FIELDS
+        ]
+        source = "this channel's own settings" if overridden else "the server's settings"
+        footer = "Pick a `field` to toggle it." if not options.field else "New update forms use these settings."
+        await reply(
+            interaction,
+            f"📝 Update questions for meetings posted in this channel ({source}):\n" + "\n".join(lines) + f"\n{footer}",
+            ephemeral=True
+        )
+        
+    except ValueError as e:
+        await reply(interaction, f"❌ {e}.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error configuring update questions: {e}")
+        await reply(interaction, "❌ Failed to update the settings. Please try again.", ephemeral=True)
+
+
+@bot.register_action("audit", requires_meeting_id=True, manager=True)
+async def handle_audit_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle showing the audit trail for a meeting."""
+    meeting_id = options.meeting_id
+    try:
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to view audit logs.", ephemeral=True)
+            return
+        
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        # Treat other servers' meetings as missing rather than confirming they exist
+        if not manages_meeting(interaction, meeting):
+            raise MeetingNotFoundError(meeting_id)
+        
+        events = await call_storage(bot.storage.load_audit_events, meeting_id)
+        if not events:
+            await reply(interaction, f"❌ No audit events found for meeting `{meeting_id}`.", ephemeral=True)
+            return
+        
+        lines = [
+            f"<t:{int(datetime.fromisoformat(event.timestamp).timestamp())}:f> **{event.event_type}** by {sanitize_for_embed(event.actor)}"
+            for event in events
+        ]
+        view = PaginatedEmbedView(title=f"📜 Audit Log for `{meeting_id}`", lines=lines)
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error showing audit log: {e}")
+        await reply(interaction, "❌ Failed to load audit log. Please try again.", ephemeral=True)
+
+
+@bot.register_action("debug", requires_meeting_id=True, manager=True)
+async def handle_debug_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle dumping a meeting's raw stored record for troubleshooting."""
+    meeting_id = options.meeting_id
+    try:
+        if not is_admin(interaction):
+            await reply(interaction, "❌ You need the Administrator permission to view raw meeting data.", ephemeral=True)
+            return
+        
+        record = await call_storage(bot.storage.load_meeting_record, meeting_id)
+        # Treat other servers' meetings as missing rather than confirming they exist
+        if record.get('guild_id') != interaction.guild_id:
+            raise MeetingNotFoundError(meeting_id)
+        counts = await call_storage(bot.storage.count_related_records, meeting_id)
+        
+        dump = json.dumps({"meeting": record, "related": counts}, indent=2, ensure_ascii=False)
+        header = f"🔧 Stored record for `{meeting_id}`"
+        # Stored text containing a code fence would break out of the block, so send it as a file instead
+        if len(dump) > DEBUG_INLINE_LIMIT or "```" in dump:
+            await reply(
+                interaction,
+                header,
+                file=discord.File(io.BytesIO(dump.encode('utf-8')), filename=f"{meeting_id}-debug.json"),
+                ephemeral=True
+            )
+        else:
+            await reply(interaction, f"{header}\n```json\n{dump}\n```", ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except json.JSONDecodeError as e:
+        await reply(interaction, f"❌ The stored record for `{meeting_id}` is not valid JSON: {e}", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error dumping meeting record: {e}")
+        await reply(interaction, "❌ Failed to load the meeting record. Please try again.", ephemeral=True)
+
+
+@bot.register_action("blockers", requires_meeting_id=True)
+async def handle_blockers(interaction: discord.Interaction, options: CommandOptions):
+    """Handle listing every reported blocker in a meeting, attributed by user."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        if not can_view_meeting(interaction, meeting):
+            await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+            return
+        
+        blockers = extract_blockers(meeting)
+        if not blockers:
+            await reply(interaction, f"🎉 Nobody reported blockers in meeting `{meeting_id}`.", ephemeral=True)
+            return
+        
+        lines = [f"**{sanitize_for_embed(user)}**: {sanitize_for_embed(text[:300])}" for user, text in blockers]
+        view = PaginatedEmbedView(title=f"🚧 Blockers for {sanitize_for_embed(meeting.name)}", lines=lines, color=0xff6b6b, per_page=5)
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error listing blockers: {e}")
+        await reply(interaction, "❌ Failed to load blockers. Please try again.", ephemeral=True)
+
+
+@bot.register_action("share", requires_meeting_id=True)
+async def handle_share_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle publishing a read-only report of a meeting and returning an expiring link to it."""
+    meeting_id = options.meeting_id
+    try:
+        if not (bot.s3_storage and bot.s3_storage.is_available()):
+            await reply(interaction, "❌ Sharing needs S3 to be configured.", ephemeral=True)
+            return
+        
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        if not can_view_meeting(interaction, meeting):
+            await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+            return
+        
+        html_content = bot.report_generator.generate_html_report(meeting)
+        if not html_content or not await asyncio.to_thread(bot.s3_storage.upload_html_report, meeting_id, html_content):
+            await reply(interaction, "❌ Failed to publish the meeting report. Please try again.", ephemeral=True)
+            return
+        
+        hours = min(options.days * 24 if options.days else SHARE_LINK_DEFAULT_HOURS, SHARE_LINK_MAX_HOURS)
+        url = bot.s3_storage.generate_presigned_url(meeting_id, expires_in=hours * 3600)
+        expires_at = int((datetime.now() + timedelta(hours=hours)).timestamp())
+        await record_audit(meeting_id, "share", interaction)
+        
+        await reply(
+            interaction,
+            f"🔗 Read-only report for **{sanitize_for_embed(meeting.name)}** (expires <t:{expires_at}:R>):\n{url}\n"
+            "It's a snapshot: run this again to share later changes.",
+            ephemeral=True
+        )
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error sharing meeting: {e}")
+        await reply(interaction, "❌ Failed to share meeting. Please try again.", ephemeral=True)
+
+
+@bot.register_action("gist", requires_meeting_id=True)
+async def handle_gist_minutes(interaction: discord.Interaction, options: CommandOptions):
+    """Handle publishing a meeting's Markdown minutes to a secret GitHub Gist."""
+    meeting_id = options.meeting_id
+    try:
+        token = get_gist_token(interaction.guild_id)
+        if not token:
+            await reply(interaction, "❌ Gist export needs a GitHub token; ask the bot's operator to set `GIST_TOKEN`.", ephemeral=True)
+            return
+        
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        if not (meeting.is_host(str(interaction.user)) or manages_meeting(interaction, meeting)):
+            await reply(interaction, "❌ Only the meeting's hosts or a manager can export its minutes.", ephemeral=True)
+            return
+        
+        minutes = bot.report_generator.generate_markdown_minutes(meeting)
+        url = await create_gist(token, f"{meeting.id}-minutes.md", minutes, f"Minutes: {meeting.name}")
+        await record_audit(meeting_id, "gist", interaction)
+        await reply(interaction, f"📤 Minutes for **{sanitize_for_embed(meeting.name)}** published as a secret gist:\n{url}", ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except GistError as e:
+        log(f"Error creating gist for meeting {meeting_id}: {e}")
+        await reply(interaction, "❌ GitHub didn't accept the gist. Check the token's gist permission and try again.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error exporting gist: {e}")
+        await reply(interaction, "❌ Failed to export the minutes. Please try again.", ephemeral=True)
+
+
+@bot.register_action("attendance", requires_meeting_id=True, manager=True)
+async def handle_attendance_sheet(interaction: discord.Interaction, options: CommandOptions):
+    """Handle exporting a meeting's attendance as a CSV file."""
+    meeting_id = options.meeting_id
+    try:
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to export attendance.", ephemeral=True)
+            return
+        
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        # Treat other servers' meetings as missing rather than confirming they exist
+        if not manages_meeting(interaction, meeting):
+            raise MeetingNotFoundError(meeting_id)
+        # Anonymous meetings don't reveal who posted, so only their roster can be exported
+        if meeting.anonymous_updates and not meeting.roster:
+            await reply(interaction, f"Meeting `{meeting_id}` has anonymous updates and no roster, so there's no attendance to export.", ephemeral=True)
+            return
+        if not (meeting.roster or meeting.updates):
+            await reply(interaction, f"Meeting `{meeting_id}` has no roster or updates to take attendance from.", ephemeral=True)
+            return
+        
+        sheet = bot.report_generator.generate_attendance_csv(meeting).encode('utf-8')
+        await record_audit(meeting_id, "attendance", interaction)
+        note = (
+            "Updates are anonymous, so only the roster is listed."
+            if meeting.anonymous_updates else "Posting an update counts as attending."
+        )
+        await reply(
+            interaction,
+            f"📋 Attendance for **{sanitize_for_embed(meeting.name)}**. {note}",
+            file=discord.File(io.BytesIO(sheet), filename=f"{meeting_id}-attendance.csv"),
+            ephemeral=True
+        )
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error exporting attendance: {e}")
+        await reply(interaction, "❌ Failed to export attendance. Please try again.", ephemeral=True)
+
+
+def build_qr_png(text: str) -> bytes:
+    """Render text as a QR code PNG, large enough to scan off a shared screen."""
+    buffer = io.BytesIO()
+    segno.make(text, error='m').save(buffer, kind='png', scale=10, border=4)
+    return buffer.getvalue()
+
+
+@bot.register_action("qr", requires_meeting_id=True)
+async def handle_meeting_qr(interaction: discord.Interaction, options: CommandOptions):
+    """Handle posting a QR code of a meeting's link."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        if not can_view_meeting(interaction, meeting):
+            await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+            return
+        
+        if not meeting.link:
+            await reply(interaction, f"❌ Meeting `{meeting_id}` has no link to make a QR code for.", ephemeral=True)
+            return
+        
+        png = build_qr_png(meeting.link)
+        embed = discord.Embed(title=f"📱 {sanitize_for_embed(meeting.name)}", description=sanitize_for_embed(meeting.link), color=0x0099ff)
+        embed.set_image(url=f"attachment://{meeting_id}-qr.png")
+        await reply(
+            interaction,
+            embed=embed,
+            file=discord.File(io.BytesIO(png), filename=f"{meeting_id}-qr.png"),
+            # Private meetings' links shouldn't end up in the channel
+            ephemeral=meeting.visibility == PRIVATE
+        )
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error generating QR code: {e}")
+        await reply(interaction, "❌ Failed to generate a QR code. Please try again.", ephemeral=True)
+
+
+def format_field_changes(change: MeetingChange) -> str:
+    """Render an edit's field changes as one `old → new` line per field, with long values cut short."""
+    def preview(value: str) -> str:
+        return sanitize_code(truncate(value, CHANGE_VALUE_PREVIEW_LENGTH)) or '(empty)'
+    
+    return "\n".join(
+        f"• **{field.field}**: `{preview(field.old)}` → `{preview(field.new)}`"
+        for field in change.changes
+    )
+
+
+async def refresh_meeting_card(meeting: Meeting):
+    """
+    Edit a meeting's posted card in place so it shows the latest status and details.
+    
+    If the card was deleted while the meeting is still open, a new card is posted
+    to the same channel and recorded in its place.
+    """
+    if not (meeting.channel_id and meeting.message_id):
+        return
+    
+    embed = build_meeting_card_embed(meeting, meeting.created_by, datetime.fromisoformat(meeting.created_at))
+    try:
+        card = await get_card_message(meeting)
+        await card.edit(embed=embed, view=build_meeting_card_view(meeting))
+        return
+    except discord.NotFound:
+        if meeting.is_closed or meeting.is_archived:
+            return
+        log(f"Card for meeting {meeting.id} was deleted; posting a new one")
+    except discord.HTTPException as e:
+        log(f"Warning: Could not refresh card for meeting {meeting.id}: {e}")
+        return
+    
+    try:
+        channel = bot.get_channel(meeting.channel_id) or await bot.fetch_channel(meeting.channel_id)
+        card = await channel.send(embed=embed, view=build_meeting_card_view(meeting))
+        meeting.message_id = card.id
+        await call_storage(bot.storage.save_meeting, meeting)
+    except (discord.HTTPException, StorageUnavailableError) as e:
+        log(f"Warning: Could not repost card for meeting {meeting.id}: {e}")
+
+
+@bot.register_action("edit", requires_meeting_id=True)
+async def handle_edit_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle opening the edit form for an open meeting."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_open_meeting, meeting_id)
+        if not meeting.is_host(str(interaction.user)):
+            await reply(interaction, "❌ Only the meeting's creator or co-hosts can do that.", ephemeral=True)
+            return
+        
+        await interaction.response.send_modal(EditMeetingModal(meeting))
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except MeetingClosedError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` is no longer open and can't be edited.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error opening edit form: {e}")
+        await reply(interaction, "❌ Failed to open the edit form. Please try again.", ephemeral=True)
+
+
+@bot.register_action("changes", requires_meeting_id=True)
+async def handle_meeting_changes(interaction: discord.Interaction, options: CommandOptions):
+    """Handle showing what was changed in a meeting's edits, when, and by whom."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        if not can_view_meeting(interaction, meeting):
+            await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+            return
+        
+        changes = await call_storage(bot.storage.load_meeting_changes, meeting_id)
+        if not changes:
+            await reply(interaction, f"Meeting `{meeting_id}` hasn't been edited.", ephemeral=True)
+            return
+        
+        lines = [
+            f"<t:{int(datetime.fromisoformat(change.changed_at).timestamp())}:f> by {sanitize_for_embed(change.changed_by)}"
+            f"{' (revert)' if change.reverted else ''}\n{format_field_changes(change)}\n"
+            for change in reversed(changes)
+        ]
+        view = PaginatedEmbedView(title=f"📝 Changes to {sanitize_for_embed(meeting.name)}", lines=lines, per_page=5)
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error showing meeting changes: {e}")
+        await reply(interaction, "❌ Failed to load meeting changes. Please try again.", ephemeral=True)
+
+
+@bot.register_action("revert", requires_meeting_id=True)
+async def handle_revert_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle undoing a meeting's most recent edit."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_open_meeting, meeting_id)
+        if str(interaction.user) != meeting.created_by and not manages_meeting(interaction, meeting):
+            await reply(interaction, "❌ Only the meeting's creator or a manager can revert edits.", ephemeral=True)
+            return
+        
+        changes = await call_storage(bot.storage.load_meeting_changes, meeting_id)
+        if not changes:
+            await reply(interaction, f"Meeting `{meeting_id}` hasn't been edited, so there's nothing to revert.", ephemeral=True)
+            return
+        
+        loaded_version = meeting.version
+        reverted = meeting.revert_change(changes[-1])
+        if not reverted:
+            await reply(interaction, f"Meeting `{meeting_id}` already matches its state before the last edit.", ephemeral=True)
+            return
+        
+        await call_storage(bot.storage.save_meeting, meeting, loaded_version)
+        change = MeetingChange(
+            meeting_id=meeting.id,
+            changed_by=str(interaction.user),
+            changed_at=datetime.now().isoformat(),
+            changes=reverted,
+            reverted=True
+        )
+        await call_storage(bot.storage.append_meeting_change, change)
+        await record_audit(meeting.id, "revert", interaction)
+        
+        await reply(interaction, f"↩️ Reverted the last edit to meeting `{meeting.id}`:\n{format_field_changes(change)}", ephemeral=True)
+        await refresh_meeting_card(meeting)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except MeetingClosedError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` is no longer open and can't be edited.", ephemeral=True)
+    except ConcurrentModificationError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` changed while reverting. Check `/meetingbot changes` and try again.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error reverting meeting: {e}")
+        await reply(interaction, "❌ Failed to revert the edit. Please try again.", ephemeral=True)
+
+
+@bot.register_action("duplicate", requires_meeting_id=True)
+async def handle_duplicate_meeting(interaction: discord.Interaction, options: CommandOptions):
+    """Handle creating a new meeting pre-filled from an existing one."""
+    meeting_id = options.meeting_id
+    try:
+        source = await call_storage(bot.storage.get_meeting, meeting_id)
+        if not can_view_meeting(interaction, source):
+            await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+            return
+        
+        # Unnamed meetings default their name to their ID, which shouldn't carry over
+        default_name = source.name if source.name != source.id else ""
+        modal = CreateMeetingModal(
+            default_name=default_name,
+            default_link=source.link or "",
+            default_location=source.location,
+            default_tags=", ".join(source.tags),
+            visibility=source.visibility,
+            anonymous_updates=source.anonymous_updates
+        )
+        await interaction.response.send_modal(modal)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error duplicating meeting: {e}")
+        await reply(interaction, "❌ Failed to duplicate meeting. Please try again.", ephemeral=True)
+
+
+@bot.register_action("import-ics", manager=True)
+async def handle_import_ics(interaction: discord.Interaction, options: CommandOptions):
+    """Handle creating meetings from the future events in an uploaded .ics file."""
+    try:
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to import meetings.", ephemeral=True)
+            return
+        
+        attachment = options.file
+        if not attachment or not attachment.filename.lower().endswith('.ics'):
+            await reply(interaction, "❌ Attach an `.ics` calendar file with the `file` option.", ephemeral=True)
+            return
+        if attachment.size > MAX_ICS_BYTES:
+            await reply(interaction, f"❌ Calendar files must be {MAX_ICS_BYTES // 1024} KB or smaller.", ephemeral=True)
+            return
+        
+        try:
+            text = (await attachment.read()).decode('utf-8')
+        except UnicodeDecodeError:
+            await reply(interaction, "❌ That file isn't a UTF-8 calendar.", ephemeral=True)
+            return
+        
+        events, skipped = parse_ics(text)
+        
+        # Imported meetings are public, so they need approval just like ones created by hand
+        approver_role_id = None
+        if interaction.guild_id:
+            settings = await call_storage(bot.storage.load_guild_settings, interaction.guild_id)
+            approver_role_id = settings.approver_role_id
+        
+        # Skip events that already exist as open meetings with the same name and link
+        existing = await call_storage(bot.storage.find_meetings, interaction.guild_id)
+        seen = {(m.name, m.link) for m in existing if not (m.is_closed or m.is_archived)}
+        
+        now = datetime.now().astimezone()
+        imported, pending = [], []
+        for event in sorted(events, key=lambda e: e.start):
+            label = event.summary or "Untitled event"
+            if event.start < now:
+                skipped.append(f"{label}: already started")
+                continue
+            if event.url and not is_valid_url(event.url):
+                skipped.append(f"{label}: invalid URL")
+                continue
+            
+            screened = screen_meeting_text(
+                interaction.guild_id,
+                event.summary[:get_max_length('MEETING_NAME')],
+                event.location[:get_max_length('MEETING_LOCATION')],
+                []
+            )
+            if screened is None:
+                # Don't echo the blocked text back; the start time is enough to find the event
+                skipped.append(f"Event at <t:{int(event.start.timestamp())}:f>: blocked by the content filter")
+                continue
+            name, location, _ = screened
+            
+            # Compare what would be stored, since long summaries are truncated
+            if (name, event.url) in seen:
+                skipped.append(f"{label}: already exists")
+                continue
+            if len(imported) >= MAX_ICS_IMPORTS:
+                skipped.append(f"{label}: import limit of {MAX_ICS_IMPORTS} reached")
+                continue
+            
+            meeting = Meeting.create_new(
+                created_by=str(interaction.user),
+                name=name,
+                link=event.url,
+                location=location
+            )
+            meeting.remember_user(str(interaction.user), interaction.user.id)
+            meeting.guild_id = interaction.guild_id
+            meeting.channel_id = interaction.channel_id
+            if approver_role_id:
+                meeting.approval = PENDING
+                pending.append(meeting)
+            await call_storage(bot.storage.create_meeting, meeting)
+            await record_audit(meeting.id, "import", interaction)
+            seen.add((name, event.url))
+            imported.append(f"`{meeting.id}` {sanitize_for_embed(meeting.name)} (<t:{int(event.start.timestamp())}:f>)")
+        
+        if imported and not pending:
+            await bot.refresh_presence()
+        
+        description = f"Imported {len(imported)} meeting(s), skipped {len(skipped)}."
+        if pending:
+            description += " They were sent for approval and will open once an approver accepts them."
+        embed = discord.Embed(
+            title="📥 Calendar Import",
+            description=description,
+            color=0x00ff00 if imported else 0xff6b6b
+        )
+        if imported:
+            embed.add_field(name="Imported", value="\n".join(imported)[:1024], inline=False)
+        if skipped:
+            embed.add_field(name="Skipped", value="\n".join(skipped)[:1024], inline=False)
+        
+        await reply(interaction, embed=embed, ephemeral=True)
+        
+        # Followups need the reply above to have been sent first
+        for meeting in pending:
+            await post_approval_request(interaction, meeting, approver_role_id)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error importing calendar: {e}")
+        await reply(interaction, "❌ Failed to import calendar. Please try again.", ephemeral=True)
+
+
+@bot.register_action("analytics", manager=True)
+async def handle_analytics(interaction: discord.Interaction, options: CommandOptions):
+    """Handle reporting participation analytics over a time window."""
+    days = options.days or 30
+    try:
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to view analytics.", ephemeral=True)
+            return
+        
+        since = datetime.now() - timedelta(days=days)
+        viewer, manager = str(interaction.user), is_manager(interaction)
+        guild_id = interaction.guild_id
+        
+        def aggregate_participation():
+            return compute_participation(
+                (meeting for meeting in bot.storage.iter_meetings()
+                 if meeting.guild_id == guild_id and meeting.is_visible_to(viewer, manager)),
+                since
+            )
+        
+        stats = await call_storage(aggregate_participation)
+        
+        embed = discord.Embed(
+            title="📊 Participation Analytics",
+            description=f"Meetings created in the last {days} day(s).",
+            color=0x3b82f6
+        )
+        embed.add_field(name="Meetings Held", value=str(stats.meetings_held), inline=True)
+        embed.add_field(name="Average Attendance", value=f"{stats.average_attendance:.1f}", inline=True)
+        if stats.average_rating is not None:
+            embed.add_field(name="Average Rating", value=f"{stats.average_rating:.1f} / 5 ({stats.rating_count} ratings)", inline=True)
+        
+        if stats.updates_by_user:
+            per_user = "\n".join(f"{sanitize_for_embed(user)}: {count}" for user, count in stats.updates_by_user.most_common(15))
+            most = "\n".join(f"{sanitize_for_embed(user)} ({count})" for user, count in stats.most_active())
+            least = "\n".join(f"{sanitize_for_embed(user)} ({count})" for user, count in stats.least_active())
+            embed.add_field(name="Updates per User", value=per_user[:1024], inline=False)
+            embed.add_field(name="Most Active", value=most, inline=True)
+            embed.add_field(name="Least Active", value=least, inline=True)
+        else:
+            embed.add_field(name="Updates per User", value="No updates in this window.", inline=False)
+        
+        await reply(interaction, embed=embed, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error computing analytics: {e}")
+        await reply(interaction, "❌ Failed to compute analytics. Please try again.", ephemeral=True)
+
+
+@bot.register_action("series-stats")
+async def handle_series_stats(interaction: discord.Interaction, options: CommandOptions):
+    """Handle showing attendance and blocker trends across the meetings sharing a tag."""
+    try:
+        if not interaction.guild_id:
+            await reply(interaction, "❌ Series stats can only be shown in a server.", ephemeral=True)
+            return
+        if not options.tag:
+            await reply(interaction, "❌ Meetings in a series share a tag; pass it with `tag`.", ephemeral=True)
+            return
+        
+        viewer, manager = str(interaction.user), is_manager(interaction)
+        meetings = [
+            meeting
+            for archived in (False, True)
+            for meeting in await call_storage(bot.storage.find_meetings, interaction.guild_id, options.tag, archived)
+            if meeting.is_visible_to(viewer, manager)
+        ]
+        if not meetings:
+            await reply(interaction, f"No meetings are tagged `{options.tag}`.", ephemeral=True)
+            return
+        
+        stats = compute_series_stats(meetings)
+        recent = stats.occurrences[-SERIES_TREND_LENGTH:]
+        trend = " → ".join(str(occurrence.participants) for occurrence in recent)
+        
+        embed = discord.Embed(
+            title=f"📈 Series: {sanitize_for_embed(options.tag)}",
+            description=f"{len(stats.occurrences)} meeting(s) since <t:{int(datetime.fromisoformat(stats.occurrences[0].created_at).timestamp())}:d>.",
+            color=0x3b82f6
+        )
+        embed.add_field(name=f"Participants (last {len(recent)})", value=trend, inline=False)
+        rate = stats.submission_rate
+        embed.add_field(name="Roster Submission Rate", value=f"{rate:.0%}" if rate is not None else "No rosters set", inline=True)
+        if stats.recurring_blockers:
+            blockers = "\n".join(f"• {sanitize_for_embed(text[:100])} ({count} meetings)" for text, count in stats.recurring_blockers)
+            embed.add_field(name="Recurring Blockers", value=blockers[:1024], inline=False)
+        else:
+            embed.add_field(name="Recurring Blockers", value="None reported more than once.", inline=False)
+        
+        await reply(interaction, embed=embed, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error computing series stats: {e}")
+        await reply(interaction, "❌ Failed to compute series stats. Please try again.", ephemeral=True)
+
+
+def build_meeting_card_embed(meeting: Meeting, creator: str, created_at: datetime) -> discord.Embed:
+    """Build the embed shown on a meeting's public card, styled for its current status."""
+    if meeting.is_archived:
+        title, status, color = "🗄️ Meeting Archived", "Archived", 0x95a5a6
+    elif meeting.is_closed:
+        title, status, color = "🔒 Meeting Closed", "Closed", 0xff6b6b
+    elif meeting.is_pending:
+        title, status, color = "⏳ Meeting Awaiting Approval", "Awaiting approval", 0xf1c40f
+    else:
+        title, status, color = "✅ New Meeting Created", "Open", 0x00ff00
+    
+    embed = discord.Embed(
+        title=title,
+        description=f"Meeting ID: `{meeting.id}`",
+        color=color
+    )
+    embed.add_field(name="Status", value=status, inline=True)
+    embed.add_field(name="Created by", value=sanitize_for_embed(creator), inline=True)
+    embed.add_field(name="Created at", value=f"<t:{int(created_at.timestamp())}:F>", inline=True)
+    embed.add_field(name="Updates", value=str(len(meeting.updates)), inline=True)
+    if meeting.location:
+        embed.add_field(name="Location", value=sanitize_for_embed(meeting.location), inline=False)
+    if meeting.tags:
+        embed.add_field(name="Tags", value=sanitize_list(meeting.tags), inline=True)
+    if meeting.co_hosts:
+        embed.add_field(name="Co-hosts", value=sanitize_list(meeting.co_hosts), inline=True)
+    if meeting.anonymous_updates:
+        embed.add_field(name="Updates are anonymous", value="Summaries won't show who wrote each update.", inline=False)
+    
+    embed.set_footer(text="Use /meetingbot update <meeting_id> to add updates")
+    return embed
+
+
+def build_create_mention() -> Tuple[Optional[str], discord.AllowedMentions]:
+    """
+    Build the ping for new meeting cards from CREATE_MENTION.
+    
+    CREATE_MENTION is a role ID, "here", or "everyone"; anything else means no ping.
+    
+    Returns:
+        (content, allowed_mentions): The mention text (or None) and mentions that may ping
+    """
+    raw = os.getenv('CREATE_MENTION', '').strip().lower()
+    if raw in ('here', 'everyone'):
+        return f"@{raw}", discord.AllowedMentions(everyone=True, roles=False, users=False)
+    if raw.isdigit():
+        return f"<@&{raw}>", discord.AllowedMentions(everyone=False, roles=[discord.Object(id=int(raw))], users=False)
+    if raw:
+        log(f"Warning: CREATE_MENTION must be a role ID, here, or everyone; got {raw!r}")
+    return None, discord.AllowedMentions.none()
+
+
+async def post_meeting_card(interaction: discord.Interaction, meeting: Meeting, embed: discord.Embed, mention: bool = False) -> Optional[discord.WebhookMessage]:
+    """Post a meeting's card as a followup, record where it landed, and pin it if configured."""
+    content, allowed_mentions = build_create_mention() if mention else (None, discord.AllowedMentions.none())
+    card = await send_followup(
+        interaction,
+        content=content,
+        embed=embed,
+        view=build_meeting_card_view(meeting),
+        allowed_mentions=allowed_mentions
+    )
+    if not card:
+        return None
+    
+    # Remember where the card was posted so exports can link back to it
+    meeting.guild_id = interaction.guild_id
+    meeting.channel_id = card.channel.id
+    meeting.message_id = card.id
+    try:
+        await call_storage(bot.storage.save_meeting, meeting)
+    except StorageUnavailableError:
+        log(f"Warning: Could not record card message for meeting {meeting.id}")
+    
+    if get_bool('PIN_MEETING_CARDS'):
+        try:
+            await card.pin(reason=f"Meeting {meeting.id} opened")
+        except discord.HTTPException as e:
+            # Most often the channel already has 50 pins
+            log(f"Warning: Could not pin card for meeting {meeting.id}: {e}")
+    return card
+
+
+@bot.register_action("announce-again", requires_meeting_id=True)
+async def handle_announce_again(interaction: discord.Interaction, options: CommandOptions):
+    """Handle reposting a meeting's card, e.g. after the original was deleted or buried."""
+    meeting_id = options.meeting_id
+    try:
+        meeting = await call_storage(bot.storage.get_meeting, meeting_id)
+        
+        if meeting.is_closed or meeting.is_archived:
+            await reply(interaction, f"❌ Meeting `{meeting_id}` is no longer open.", ephemeral=True)
+            return
+        
+        if meeting.is_pending:
+            await reply(interaction, f"❌ Meeting `{meeting_id}` is announced once it's approved.", ephemeral=True)
+            return
+        
+        if meeting.visibility == PRIVATE:
+            await reply(interaction, f"❌ Meeting `{meeting_id}` is private and isn't announced in channels.", ephemeral=True)
+            return
+        
+        if not meeting.is_host(str(interaction.user)):
+            await reply(interaction, f"❌ Only the meeting's creator or co-hosts can do that.", ephemeral=True)
+            return
+        
+        # Unpin the old card so only the current one stays pinned; its buttons keep working
+        if get_bool('PIN_MEETING_CARDS'):
+            try:
+                old_card = await get_card_message(meeting)
+                if old_card:
+                    await old_card.unpin(reason=f"Meeting {meeting_id} announced again")
+            except discord.HTTPException as e:
+                log(f"Warning: Could not unpin old card for meeting {meeting_id}: {e}")
+        
+        await reply(interaction, f"✅ Meeting `{meeting_id}` has been announced again.", ephemeral=True)
+        
+        embed = build_meeting_card_embed(meeting, meeting.created_by, datetime.fromisoformat(meeting.created_at))
+        if await post_meeting_card(interaction, meeting, embed):
+            await record_audit(meeting_id, "announce-again", interaction)
+        
+    except MeetingNotFoundError:
+        await reply(interaction, f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error announcing meeting again: {e}")
+        await reply(interaction, "❌ Failed to announce meeting. Please try again.", ephemeral=True)
+
+
+def build_meeting_card_view(meeting: Meeting) -> Optional[discord.ui.View]:
+    """Build the buttons attached to a meeting card, if any apply."""
+    rateable = meeting.is_closed and not meeting.is_archived
+    if not (meeting.link or rateable):
+        return None
+    view = discord.ui.View(timeout=None)
+    if meeting.link:
+        view.add_item(CopyLinkButton(meeting.id))
+    if rateable:
+        view.add_item(RateMeetingButton(meeting.id))
+    return view
+
+
+def build_rating_view(meeting_id: str) -> discord.ui.View:
+    """Build the view holding just the Rate button, for close summaries."""
+    view = discord.ui.View(timeout=None)
+    view.add_item(RateMeetingButton(meeting_id))
+    return view
+
+
+class CopyLinkButton(discord.ui.DynamicItem[discord.ui.Button], template=r'meetingbot:copy-link:(?P<meeting_id>[\w-]+)'):
+    """Button that privately sends the clicker a meeting's raw link."""
+    
+    def __init__(self, meeting_id: str):
+        super().__init__(
+            discord.ui.Button(
+                label="Copy link",
+                emoji="🔗",
+                style=discord.ButtonStyle.secondary,
+                custom_id=f"meetingbot:copy-link:{meeting_id}"
+            )
+        )
+        self.meeting_id = meeting_id
+    
+    @classmethod
+    async def from_custom_id(cls, interaction: discord.Interaction, item: discord.ui.Button, match):
+        return cls(match['meeting_id'])
+    
+    async def callback(self, interaction: discord.Interaction):
+        """Respond ephemerally with the link in a code block for easy copying."""
+        start_request()
+        try:
+            meeting = await call_storage(bot.storage.get_meeting, self.meeting_id)
+            # Custom IDs can be forged, so the button alone doesn't prove the clicker may see the meeting
+            if not can_view_meeting(interaction, meeting):
+                raise MeetingNotFoundError(self.meeting_id)
+            if not meeting.link:
+                await reply(interaction, "❌ This meeting has no link.", ephemeral=True)
+                return
+            
+            created_at = int(datetime.fromisoformat(meeting.created_at).timestamp())
+            await reply(
+                interaction,
+                f"```\n{meeting.link.replace('`', '')}\n```\n**{sanitize_for_embed(meeting.name)}** (created <t:{created_at}:F>)",
+                ephemeral=True
+            )
+            
+        except MeetingNotFoundError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` not found.", ephemeral=True)
+        except StorageUnavailableError:
+            await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+        except Exception as e:
+            log(f"Error copying link for meeting {self.meeting_id}: {e}")
+            await reply(interaction, "❌ Failed to get meeting link. Please try again.", ephemeral=True)
+
+
+async def request_approval(interaction: discord.Interaction, meeting: Meeting, approver_role_id: int):
+    """Tell the creator their meeting is pending and ask the approver role to review it."""
+    await reply(
+        interaction,
+        f"⏳ Meeting `{meeting.id}` was sent for approval. It will be announced here once an approver accepts it.",
+        ephemeral=True
+    )
+    await post_approval_request(interaction, meeting, approver_role_id)
+
+
+async def post_approval_request(interaction: discord.Interaction, meeting: Meeting, approver_role_id: int):
+    """Ask the approver role to review a pending meeting, as a followup in the interaction's channel."""
+    view = discord.ui.View(timeout=None)
+    view.add_item(ReviewMeetingButton(meeting.id, approved=True))
+    view.add_item(ReviewMeetingButton(meeting.id, approved=False))
+    await send_followup(
+        interaction,
+        content=f"<@&{approver_role_id}> A new meeting needs approval.",
+        embed=build_meeting_card_embed(meeting, interaction.user.mention, interaction.created_at),
+        view=view,
+        allowed_mentions=discord.AllowedMentions(roles=[discord.Object(id=approver_role_id)])
+    )
+
+
+def can_review_meetings(interaction: discord.Interaction, approver_role_id: Optional[int]) -> bool:
+    """Check whether the user may approve or reject meetings; administrators always can."""
+    if is_admin(interaction):
+        return True
+    return (
+        approver_role_id is not None
+        and isinstance(interaction.user, discord.Member)
+        and interaction.user.get_role(approver_role_id) is not None
+    )
+
+
+class ReviewMeetingButton(discord.ui.DynamicItem[discord.ui.Button], template=r'meetingbot:review:(?P<decision>approve|reject):(?P<meeting_id>[\w-]+)'):
+    """Button on an approval request that approves or rejects a pending meeting."""
+    
+    def __init__(self, meeting_id: str, approved: bool):
+        decision = "approve" if approved else "reject"
+        super().__init__(
+            discord.ui.Button(
+                label="Approve" if approved else "Reject",
+                emoji="✅" if approved else "✖️",
+                style=discord.ButtonStyle.success if approved else discord.ButtonStyle.danger,
+                custom_id=f"meetingbot:review:{decision}:{meeting_id}"
+            )
+        )
+        self.meeting_id = meeting_id
+        self.approved = approved
+    
+    @classmethod
+    async def from_custom_id(cls, interaction: discord.Interaction, item: discord.ui.Button, match):
+        return cls(match['meeting_id'], approved=match['decision'] == "approve")
+    
+    async def callback(self, interaction: discord.Interaction):
+        """Record the decision, then announce the meeting if it was approved."""
+        start_request()
+        try:
+            settings = await call_storage(bot.storage.load_guild_settings, interaction.guild_id)
+            if not can_review_meetings(interaction, settings.approver_role_id):
+                await reply(interaction, "❌ Only members of the approver role can review meetings.", ephemeral=True)
+                return
+            
+            meeting = await call_storage(bot.storage.get_meeting, self.meeting_id)
+            if not meeting.is_pending:
+                await reply(
+                    interaction,
+                    f"Meeting `{self.meeting_id}` was already {meeting.approval} by {sanitize_for_embed(meeting.reviewed_by or 'someone')}.",
+                    ephemeral=True
+                )
+                return
+            
+            reviewer = str(interaction.user)
+            meeting.review(reviewer, self.approved)
+            # Two approvers clicking at once must not both announce the meeting
+            await call_storage(bot.storage.save_meeting, meeting, meeting.version)
+            await record_audit(self.meeting_id, "approve" if self.approved else "reject", interaction)
+            log(f"{reviewer} {meeting.approval} meeting {self.meeting_id}")
+            
+            embed = build_meeting_card_embed(meeting, meeting.created_by, datetime.fromisoformat(meeting.created_at))
+            if not self.approved:
+                await interaction.response.edit_message(
+                    content=f"✖️ Rejected by {interaction.user.mention}; the meeting was closed.",
+                    embed=embed,
+                    view=None
+                )
+                return
+            
+            await interaction.response.edit_message(content=f"✅ Approved by {interaction.user.mention}.", embed=embed, view=None)
+            await post_meeting_card(interaction, meeting, embed, mention=True)
+            await bot.refresh_presence()
+            
+        except MeetingNotFoundError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` not found.", ephemeral=True)
+        except ConcurrentModificationError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` was reviewed by someone else at the same time.", ephemeral=True)
+        except StorageUnavailableError:
+            await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+        except Exception as e:
+            log(f"Error reviewing meeting {self.meeting_id}: {e}")
+            await reply(interaction, "❌ Failed to record the decision. Please try again.", ephemeral=True)
+
+
+def format_meeting_line(meeting: Meeting) -> str:
+    """Render a meeting as a single list line."""
+    if meeting.is_archived:
+        status = "🗄️"
+    elif meeting.is_closed:
+        status = "🔒"
+    else:
+        status = "⏳" if meeting.is_pending else "🟢"
+    tags = f" · {sanitize_list(meeting.tags)}" if meeting.tags else ""
+    private = " 🔐" if meeting.visibility == PRIVATE else ""
+    return f"{status} `{meeting.id}` **{sanitize_for_embed(meeting.name)}**{private} ({len(meeting.updates)} updates){tags}"
+
+
+def build_digest_embed(meetings: List[Meeting]) -> Optional[discord.Embed]:
+    """
+    Build the scheduled open meetings digest; private meetings and ones awaiting approval are left out.
+    
+    Returns:
+        The embed, or None if there are no open meetings to show
+    """
+    open_meetings = [m for m in meetings if not (m.is_closed or m.is_pending) and m.visibility != PRIVATE]
+    if not open_meetings:
+        return None
+    
+    lines = [format_meeting_line(meeting) for meeting in open_meetings[:DIGEST_MAX_MEETINGS]]
+    if len(open_meetings) > DIGEST_MAX_MEETINGS:
+        lines.append(f"…and {len(open_meetings) - DIGEST_MAX_MEETINGS} more; see `/meetingbot list`")
+    return discord.Embed(
+        title=f"☀️ {len(open_meetings)} Open Meetings",
+        description="\n".join(lines),
+        color=0x00ff00
+    )
+
+
+def build_empty_digest_embed() -> discord.Embed:
+    """Build the pinned digest shown while no meetings are open."""
+    return discord.Embed(
+        title="☀️ No Open Meetings",
+        description="Nothing is open right now. Start one with `/meetingbot new`.",
+        color=0x95a5a6
+    )
+
+
+def format_meeting_details(meeting: Meeting) -> str:
+    """Render a meeting as a multi-line list entry with its creator, time, and where to join."""
+    created_at = int(datetime.fromisoformat(meeting.created_at).timestamp())
+    lines = [format_meeting_line(meeting), f"> Created by {sanitize_for_embed(meeting.created_by)} <t:{created_at}:R>"]
+    if meeting.co_hosts:
+        lines.append(f"> Co-hosts: {sanitize_list(meeting.co_hosts)}")
+    if meeting.link:
+        lines.append(f"> Link: {sanitize_for_embed(meeting.link)}")
+    if meeting.location:
+        lines.append(f"> Location: {sanitize_for_embed(meeting.location)}")
+    if meeting.jump_url:
+        lines.append(f"> [Meeting post]({meeting.jump_url})")
+    return "\n".join(lines) + "\n"
+
+
+@bot.register_action("list")
+async def handle_list_meetings(interaction: discord.Interaction, options: CommandOptions):
+    """Handle listing meetings in the current server, optionally filtered by tag."""
+    try:
+        if not interaction.guild_id:
+            await reply(interaction, "❌ Meetings can only be listed in a server.", ephemeral=True)
+            return
+        
+        # An explicit compact choice becomes the user's default for later lists
+        prefs = await call_storage(bot.storage.load_user_prefs, interaction.user.id)
+        if options.compact is not None and options.compact != prefs.compact_list:
+            prefs.compact_list = options.compact
+            await call_storage(bot.storage.save_user_prefs, prefs)
+
+        requested_tags = parse_tags(options.tag or "")
+        tag = requested_tags[0] if requested_tags else None
+        meetings = await call_storage(bot.storage.find_meetings, interaction.guild_id, tag, options.archived)
+        viewer, manager = str(interaction.user), is_manager(interaction)
+        meetings = [meeting for meeting in meetings if meeting.is_visible_to(viewer, manager)]
+        
+        if prefs.compact_list:
+            lines, per_page = [format_meeting_line(meeting) for meeting in meetings], 10
+        else:
+            lines, per_page = [format_meeting_details(meeting) for meeting in meetings], 4
+        
+        title = f"📋 Meetings tagged `{tag}`" if tag else "📋 Meetings"
+        if options.archived:
+            title = title.replace("Meetings", "Archived meetings", 1)
+        view = PaginatedEmbedView(title=title, lines=lines, per_page=per_page)
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except StorageUnavailableError:
+        # Don't show an empty list that looks like there are no meetings
+        await reply(interaction, f"{STORAGE_UNAVAILABLE_MESSAGE} (meeting list is degraded)", ephemeral=True)
+    except Exception as e:
+        log(f"Error listing meetings: {e}")
+        await reply(interaction, "❌ Failed to list meetings. Please try again.", ephemeral=True)
+
+
+def day_formats(locale: Optional[discord.Locale]) -> List[str]:
+    """Day layouts to try for a user's locale, most likely first; ISO always works."""
+    if locale in MONTH_FIRST_LOCALES:
+        formats = ["%m/%d/%Y", "%m-%d-%Y"]
+    elif locale in YEAR_FIRST_LOCALES:
+        formats = ["%Y/%m/%d", "%Y.%m.%d"]
+    else:
+        formats = ["%d/%m/%Y", "%d.%m.%Y", "%d-%m-%Y"]
+    return formats + ["%Y-%m-%d"]
+
+
+def parse_day(value: str, locale: Optional[discord.Locale] = None) -> datetime:
+    """
+    Parse a day as local midnight, reading slashed dates the way the user's locale writes them
+    (e.g. 06/01/2024 is June 1 for en-US and January 6 for en-GB).
     
-    async def on_command_error(self, ctx, error):
-        """Handle command errors."""
-        if isinstance(error, commands.CommandNotFound):
+    Raises:
+        ValueError: If the value doesn't match any layout for the locale
+    """
+    for layout in day_formats(locale):
+        try:
+            return datetime.strptime(value.strip(), layout)
+        except ValueError:
+            continue
+    raise ValueError(f"Unrecognized day: {value!r}")
+
+
+def format_day(day: datetime) -> str:
+    """Spell out a day, e.g. "June 1, 2024", so mis-parsed dates are easy to spot."""
+    return f"{day:%B} {day.day}, {day.year}"
+
+
+@bot.register_action("between")
+async def handle_meetings_between(interaction: discord.Interaction, options: CommandOptions):
+    """Handle listing meetings created between two days, oldest first."""
+    try:
+        if not interaction.guild_id:
+            await reply(interaction, "❌ Meetings can only be listed in a server.", ephemeral=True)
+            return
+        if not (options.start and options.end):
+            await reply(interaction, "❌ Give both `start` and `end` days, e.g. 2025-09-01.", ephemeral=True)
             return
         
-        print(f"Command error: {error}")
-        await ctx.send(f"An error occurred: {str(error)}")
+        try:
+            start, last_day = parse_day(options.start, interaction.locale), parse_day(options.end, interaction.locale)
+        except ValueError:
+            example = datetime(2025, 9, 1).strftime(day_formats(interaction.locale)[0])
+            await reply(interaction, f"❌ Days must look like {example} or 2025-09-01.", ephemeral=True)
+            return
+        if start > last_day:
+            await reply(interaction, "❌ `start` must be on or before `end`.", ephemeral=True)
+            return
+        
+        # The end day is included, so the range runs up to the following midnight
+        meetings = await call_storage(bot.storage.find_meetings_between, interaction.guild_id, start, last_day + timedelta(days=1))
+        viewer, manager = str(interaction.user), is_manager(interaction)
+        meetings = [meeting for meeting in meetings if meeting.is_visible_to(viewer, manager)]
+        
+        lines = [
+            f"<t:{int(datetime.fromisoformat(meeting.created_at).timestamp())}:d> {format_meeting_line(meeting)}"
+            for meeting in meetings
+        ]
+        title = f"📆 Meetings from {format_day(start)} to {format_day(last_day)}"
+        view = PaginatedEmbedView(title=title, lines=lines)
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error listing meetings between days: {e}")
+        await reply(interaction, "❌ Failed to list meetings. Please try again.", ephemeral=True)
 
 
-# Create bot instance
-bot = MeetingBot()
+@bot.register_action("resync", manager=True)
+async def handle_resync_commands(interaction: discord.Interaction, options: CommandOptions):
+    """Handle re-registering this server's commands without restarting the bot."""
+    try:
+        if not interaction.guild:
+            await reply(interaction, "❌ Commands can only be resynced in a server.", ephemeral=True)
+            return
+        if not is_admin(interaction):
+            await reply(interaction, "❌ You need the Administrator permission to resync commands.", ephemeral=True)
+            return
+        
+        # Syncing can take longer than Discord's 3 second response window
+        await interaction.response.defer(ephemeral=True, thinking=True)
+        added, removed, changed = await bot.resync_guild(interaction.guild.id)
+        log(f"Resynced commands for guild {interaction.guild.id}: +{added} -{removed} changed={changed}")
+        
+        lines = [f"🔄 Resynced commands for **{interaction.guild.name}**."]
+        if added:
+            lines.append(f"Added: {', '.join(f'`{name}`' for name in added)}")
+        if removed:
+            lines.append(f"Removed: {', '.join(f'`{name}`' for name in removed)}")
+        if not (added or removed):
+            lines.append("Definitions changed since the last sync." if changed else "Nothing changed since the last sync.")
+        await reply(interaction, "\n".join(lines), ephemeral=True)
+        
+    except discord.HTTPException as e:
+        log(f"Error resyncing commands: {e}")
+        await reply(interaction, f"❌ Discord rejected the command sync: {e.text or e}", ephemeral=True)
+    except Exception as e:
+        log(f"Error resyncing commands: {e}")
+        await reply(interaction, "❌ Failed to resync commands. Please try again.", ephemeral=True)
 
 
-@bot.tree.command(name="meetingbot", description="Meeting bot commands")
-@app_commands.describe(action="Action to perform", meeting_id="Meeting ID (for update/close)")
-@app_commands.choices(action=[
-    app_commands.Choice(name="new", value="new"),
-    app_commands.Choice(name="update", value="update"),
-    app_commands.Choice(name="close", value="close")
-])
-async def meetingbot_command(interaction: discord.Interaction, action: str, meeting_id: Optional[str] = None):
-    """Main slash command handler."""
-    
-    if action == "new":
-        await handle_new_meeting(interaction)
-    elif action == "update":
-        if not meeting_id:
-            await interaction.response.send_message("❌ Meeting ID is required for update command.", ephemeral=True)
+async def set_guild_disabled(interaction: discord.Interaction, disabled: bool):
+    """Turn maintenance mode on or off for the invoking server."""
+    try:
+        if not interaction.guild_id:
+            await reply(interaction, "❌ The bot can only be disabled in a server.", ephemeral=True)
             return
-        await handle_update_meeting(interaction, meeting_id)
-    elif action == "close":
-        if not meeting_id:
-            await interaction.response.send_message("❌ Meeting ID is required for close command.", ephemeral=True)
+        if not is_admin(interaction):
+            await reply(interaction, "❌ You need the Administrator permission to do that.", ephemeral=True)
             return
-        await handle_close_meeting(interaction, meeting_id)
+        
+        settings = await call_storage(bot.storage.load_guild_settings, interaction.guild_id)
+        if settings.disabled == disabled:
+            await reply(interaction, f"The meeting bot is already {'disabled' if disabled else 'enabled'} here.", ephemeral=True)
+            return
+        
+        settings.disabled = disabled
+        await call_storage(bot.storage.save_guild_settings, settings)
+        log(f"{interaction.user} {'disabled' if disabled else 'enabled'} the bot in guild {interaction.guild_id}")
+        
+        if disabled:
+            message = "🛠️ The meeting bot is now disabled for everyone but administrators. Use `/meetingbot-manage enable` to turn it back on."
+        else:
+            message = "✅ The meeting bot is enabled again."
+        await reply(interaction, message, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error changing maintenance mode: {e}")
+        await reply(interaction, "❌ Failed to change the setting. Please try again.", ephemeral=True)
 
 
-async def handle_new_meeting(interaction: discord.Interaction):
-    """Handle creating a new meeting."""
+@bot.register_action("disable", manager=True)
+async def handle_disable_bot(interaction: discord.Interaction, options: CommandOptions):
+    """Handle putting the bot into maintenance mode for this server."""
+    await set_guild_disabled(interaction, True)
+
+
+@bot.register_action("enable", manager=True)
+async def handle_enable_bot(interaction: discord.Interaction, options: CommandOptions):
+    """Handle taking the bot out of maintenance mode for this server."""
+    await set_guild_disabled(interaction, False)
+
+
+@bot.register_action("approvals", manager=True)
+async def handle_approvals(interaction: discord.Interaction, options: CommandOptions):
+    """Handle showing or setting the role that must approve new public meetings."""
     try:
-        modal = CreateMeetingModal()
-        await interaction.response.send_modal(modal)
+        if not interaction.guild_id:
+            await reply(interaction, "❌ Approvals can only be configured in a server.", ephemeral=True)
+            return
+        if not is_admin(interaction):
+            await reply(interaction, "❌ You need the Administrator permission to change approvals.", ephemeral=True)
+            return
+        
+        settings = await call_storage(bot.storage.load_guild_settings, interaction.guild_id)
+        if options.role:
+            settings.approver_role_id = options.role.id
+            await call_storage(bot.storage.save_guild_settings, settings)
+            log(f"{interaction.user} required approval by role {options.role.id} in guild {interaction.guild_id}")
+        
+        if settings.approver_role_id:
+            message = (
+                f"✅ New public meetings wait for <@&{settings.approver_role_id}> to approve them before they're announced. "
+                "Use `/meetingbot-manage approvals-off` to stop requiring approval."
+            )
+        else:
+            message = "New meetings are announced right away. Pass a `role` to require its approval first."
+        await reply(interaction, message, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error configuring approvals: {e}")
+        await reply(interaction, "❌ Failed to update the settings. Please try again.", ephemeral=True)
+
 
+@bot.register_action("approvals-off", manager=True)
+async def handle_approvals_off(interaction: discord.Interaction, options: CommandOptions):
+    """Handle announcing new meetings without approval again."""
+    try:
+        if not interaction.guild_id:
+            await reply(interaction, "❌ Approvals can only be configured in a server.", ephemeral=True)
+            return
+        if not is_admin(interaction):
+            await reply(interaction, "❌ You need the Administrator permission to change approvals.", ephemeral=True)
+            return
+        
+        settings = await call_storage(bot.storage.load_guild_settings, interaction.guild_id)
+        if not settings.approver_role_id:
+            await reply(interaction, "New meetings already don't need approval here.", ephemeral=True)
+            return
+        
+        settings.approver_role_id = None
+        await call_storage(bot.storage.save_guild_settings, settings)
+        log(f"{interaction.user} stopped requiring approval in guild {interaction.guild_id}")
+        await reply(
+            interaction,
+            "✅ New meetings are announced right away again. Meetings already waiting can still be reviewed by administrators.",
+            ephemeral=True
+        )
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
     except Exception as e:
-        print(f"Error creating meeting: {e}")
-        await interaction.response.send_message("❌ Failed to create meeting. Please try again.", ephemeral=True)
+        log(f"Error configuring approvals: {e}")
+        await reply(interaction, "❌ Failed to update the settings. Please try again.", ephemeral=True)
 
 
-async def handle_update_meeting(interaction: discord.Interaction, meeting_id: str):
-    """Handle updating a meeting with a modal form."""
+def parse_post_time(value: str, locale: Optional[discord.Locale] = None) -> datetime:
+    """
+    Parse "<day> HH:MM" as a UTC time, reading the day the way parse_day does.
+    
+    Raises:
+        ValueError: If either part doesn't parse
+    """
+    day_text, _, clock_text = value.strip().rpartition(" ")
+    if not day_text:
+        raise ValueError(f"Missing time of day: {value!r}")
+    clock = datetime.strptime(clock_text, "%H:%M")
+    return parse_day(day_text, locale).replace(hour=clock.hour, minute=clock.minute, tzinfo=timezone.utc)
+
+
+@bot.register_action("announce", manager=True)
+async def handle_schedule_announcement(interaction: discord.Interaction, options: CommandOptions):
+    """Handle scheduling a plain message to be posted to a channel later."""
     try:
-        meeting = bot.storage.load_meeting(meeting_id)
-        if not meeting:
-            await interaction.response.send_message(f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to schedule announcements.", ephemeral=True)
+            return
+        if not (options.text and options.at):
+            await reply(interaction, "❌ Give the message with `text` and when to post it with `at`, e.g. `2024-06-01 09:30` (UTC).", ephemeral=True)
             return
         
-        if meeting.is_closed:
-            await interaction.response.send_message(f"❌ Meeting `{meeting_id}` is closed and cannot be updated.", ephemeral=True)
+        try:
+            post_at = parse_post_time(options.at, interaction.locale)
+        except ValueError:
+            await reply(interaction, f"❌ Couldn't read `{sanitize_code(options.at)}`. Use a day and a 24-hour UTC time, e.g. `2024-06-01 09:30`.", ephemeral=True)
+            return
+        if post_at <= discord.utils.utcnow():
+            await reply(interaction, f"❌ <t:{int(post_at.timestamp())}:F> has already passed.", ephemeral=True)
+            return
+        
+        channel = options.channel or interaction.channel
+        if not channel.permissions_for(interaction.guild.me).send_messages:
+            await reply(interaction, f"❌ I can't send messages in {channel.mention}.", ephemeral=True)
+            return
+        
+        pending = await call_storage(bot.storage.find_announcements, interaction.guild_id)
+        if len(pending) >= MAX_PENDING_ANNOUNCEMENTS:
+            await reply(interaction, f"❌ This server already has {MAX_PENDING_ANNOUNCEMENTS} scheduled announcements. Cancel one first.", ephemeral=True)
             return
+        
+        announcement = Announcement.create_new(
+            guild_id=interaction.guild_id,
+            channel_id=channel.id,
+            created_by=str(interaction.user),
+            message=options.text,
+            post_at=post_at
+        )
+        await call_storage(bot.storage.save_announcement, announcement)
+        log(f"{interaction.user} scheduled announcement {announcement.id} for {announcement.post_at} in channel {channel.id}")
+        await reply(
+            interaction,
+            f"📣 Announcement `{announcement.id}` will be posted in {channel.mention} <t:{int(post_at.timestamp())}:F>. "
+            f"Cancel it with `/{MANAGE_COMMAND_NAME} announce-cancel`.",
+            ephemeral=True
+        )
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error scheduling announcement: {e}")
+        await reply(interaction, "❌ Failed to schedule the announcement. Please try again.", ephemeral=True)
 
-        # Check if user has already submitted an update for this meeting
-        user_str = str(interaction.user)
-        if any(update.user == user_str for update in meeting.updates):
-            await interaction.response.send_message(f"❌ You have already submitted an update for meeting `{meeting_id}`.", ephemeral=True)
+
+@bot.register_action("announcements", manager=True)
+async def handle_list_announcements(interaction: discord.Interaction, options: CommandOptions):
+    """Handle listing the server's scheduled announcements, soonest first."""
+    try:
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to view scheduled announcements.", ephemeral=True)
             return
         
-        modal = UpdateModal(meeting_id)
-        await interaction.response.send_modal(modal)
+        announcements = await call_storage(bot.storage.find_announcements, interaction.guild_id)
+        if not announcements:
+            await reply(interaction, f"No announcements are scheduled. Add one with `/{MANAGE_COMMAND_NAME} announce`.", ephemeral=True)
+            return
+        
+        def describe(announcement: Announcement) -> str:
+            posted_at = int(datetime.fromisoformat(announcement.post_at).timestamp())
+            preview = announcement.message[:ANNOUNCEMENT_PREVIEW_LENGTH] + ("…" if len(announcement.message) > ANNOUNCEMENT_PREVIEW_LENGTH else "")
+            return f"`{announcement.id}` <t:{posted_at}:f> in <#{announcement.channel_id}> by {sanitize_for_embed(announcement.created_by)}\n> {sanitize_for_embed(preview)}"
         
+        view = PaginatedEmbedView(title="📣 Scheduled Announcements", lines=[describe(a) for a in announcements], per_page=5)
+        await reply(interaction, embed=view.build_embed(), view=view, ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
     except Exception as e:
-        print(f"Error handling update: {e}")
-        await interaction.response.send_message("❌ Failed to process update request. Please try again.", ephemeral=True)
+        log(f"Error listing announcements: {e}")
+        await reply(interaction, "❌ Failed to list announcements. Please try again.", ephemeral=True)
 
 
-async def handle_close_meeting(interaction: discord.Interaction, meeting_id: str):
-    """Handle closing a meeting."""
+@bot.register_action("announce-cancel", manager=True)
+async def handle_cancel_announcement(interaction: discord.Interaction, options: CommandOptions):
+    """Handle cancelling a scheduled announcement before it's posted."""
+    announcement_id = options.announcement_id
     try:
-        meeting = bot.storage.load_meeting(meeting_id)
-        if not meeting:
-            await interaction.response.send_message(f"❌ Meeting `{meeting_id}` not found.", ephemeral=True)
+        if not is_manager(interaction):
+            await reply(interaction, "❌ You need the Manage Events permission to cancel announcements.", ephemeral=True)
+            return
+        if not announcement_id:
+            await reply(interaction, "❌ Give the announcement to cancel with `announcement_id`.", ephemeral=True)
             return
         
-        if meeting.is_closed:
-            await interaction.response.send_message(f"❌ Meeting `{meeting_id}` is already closed.", ephemeral=True)
+        # IDs are short hex strings; anything else could name a path outside the announcements folder
+        announcement_id = announcement_id.strip().lower()
+        announcement = None
+        if re.fullmatch(r'[0-9a-f]{8}', announcement_id):
+            announcement = await call_storage(bot.storage.load_announcement, announcement_id)
+        # Other servers' announcements are treated as missing rather than confirming they exist
+        if not announcement or announcement.guild_id != interaction.guild_id:
+            await reply(interaction, f"❌ No scheduled announcement `{sanitize_code(announcement_id)}`; it may already have been posted.", ephemeral=True)
             return
         
-        if str(interaction.user) != meeting.created_by:
-            await interaction.response.send_message(f"❌ You did not open this meeting.", ephemeral=True)
+        if not await call_storage(bot.storage.delete_announcement, announcement.id):
+            await reply(interaction, f"❌ Announcement `{announcement.id}` was posted or cancelled just now.", ephemeral=True)
             return
+        log(f"{interaction.user} cancelled announcement {announcement.id}")
+        await reply(interaction, f"🗑️ Announcement `{announcement.id}` was cancelled and won't be posted.", ephemeral=True)
+        
+    except StorageUnavailableError:
+        await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+    except Exception as e:
+        log(f"Error cancelling announcement: {e}")
+        await reply(interaction, "❌ Failed to cancel the announcement. Please try again.", ephemeral=True)
 
-        meeting.close()
-        bot.storage.save_meeting(meeting)
+
+async def set_schedulers_paused(interaction: discord.Interaction, paused: bool):
+    """Pause or resume the bot's scheduled posts in every server."""
+    try:
+        # This affects every server the bot is in, so server administrators aren't enough
+        if not await bot.is_owner(interaction.user):
+            await reply(interaction, "❌ Only the bot's owner can pause or resume scheduled posts.", ephemeral=True)
+            return
         
-        # Upload to S3 (silent operation)
-        if bot.s3_storage and bot.s3_storage.is_available():
-            try:
-                # Generate HTML report
-                html_content = bot.report_generator.generate_html_report(meeting)
-                if html_content:
-                    bot.s3_storage.upload_meeting_json(meeting_id, meeting.to_dict())
-                    bot.s3_storage.upload_html_report(meeting_id, html_content)
-                else:
-                    print(f"Warning: Could not generate HTML report for meeting {meeting_id}")
-                
-                presigned_url = bot.s3_storage.generate_presigned_url(meeting_id)
-            except Exception as e:
-                print(f"Warning: S3 upload failed for meeting {meeting_id}: {e}")
-                # Continue with Discord response even if S3 fails
+        if bot.schedulers_paused == paused:
+            await reply(interaction, f"Scheduled posts are already {'paused' if paused else 'running'}.", ephemeral=True)
+            return
+        
+        bot.schedulers_paused = paused
+        log(f"{interaction.user} {'paused' if paused else 'resumed'} scheduled posts")
+        
+        if paused:
+            message = "⏸️ Scheduled posts are paused in every server until `/meetingbot resume-schedulers` or a restart."
         else:
-            print(f"S3 not available, skipping upload for meeting {meeting_id}")
+            message = "▶️ Scheduled posts are running again."
+        await reply(interaction, message, ephemeral=True)
         
-        presigned_url = presigned_url if presigned_url else "Automatic presigned url unavailable"
+    except Exception as e:
+        log(f"Error changing scheduler state: {e}")
+        await reply(interaction, "❌ Failed to change the setting. Please try again.", ephemeral=True)
+
+
+@bot.register_action("pause-schedulers")
+async def handle_pause_schedulers(interaction: discord.Interaction, options: CommandOptions):
+    """Handle halting scheduled posts without stopping the bot."""
+    await set_schedulers_paused(interaction, True)
+
+
+@bot.register_action("resume-schedulers")
+async def handle_resume_schedulers(interaction: discord.Interaction, options: CommandOptions):
+    """Handle restarting scheduled posts after a pause."""
+    await set_schedulers_paused(interaction, False)
+
+
+@bot.register_action("cache-stats")
+async def handle_cache_stats(interaction: discord.Interaction, options: CommandOptions):
+    """Handle reporting how well the storage cache is doing."""
+    try:
+        # The cache is shared by every server, so its numbers aren't any one server's business
+        if not await bot.is_owner(interaction.user):
+            await reply(interaction, "❌ Only the bot's owner can view cache statistics.", ephemeral=True)
+            return
         
-        # Generate summary
-        embed = discord.Embed(
-            title="🔒 Meeting Closed",
-            description=f"Meeting `{meeting_id}` has been closed.",
-            color=0xff6b6b
+        stats = bot.storage.cache.stats()
+        log(f"Storage cache: {stats['hits']} hits, {stats['misses']} misses, {stats['entries']} entries")
+        await reply(
+            interaction,
+            f"🗄️ Storage cache: {stats['hits']} hits, {stats['misses']} misses, {stats['entries']} live entries.",
+            ephemeral=True
         )
+        
+    except Exception as e:
+        log(f"Error reading cache statistics: {e}")
+        await reply(interaction, "❌ Failed to read cache statistics. Please try again.", ephemeral=True)
 
-        embed.add_field(name="Total Updates", value=str(len(meeting.updates)), inline=True)
-        embed.add_field(name="Closed by", value=interaction.user.mention, inline=True)
-        embed.add_field(name="Closed at", value=f"<t:{int(interaction.created_at.timestamp())}:F>", inline=True)
 
-        embed.add_field(name="View meeting report at presigned url:", value=presigned_url, inline=False)
+@bot.register_action("check-permissions")
+async def handle_check_permissions(interaction: discord.Interaction, options: CommandOptions):
+    """Handle reporting the bot's effective permissions in the current channel."""
+    try:
+        results = interpret_permissions(interaction.app_permissions)
+        missing_required = [label for label, granted, required in results if required and not granted]
         
-        link = meeting.link if meeting.link else "This meeting has no link."
-        embed.add_field(name="Join meeting at link:", value=link, inline=False)
+        lines = []
+        for label, granted, required in results:
+            icon = "✅" if granted else ("❌" if required else "⚠️")
+            suffix = "" if required else " (optional)"
+            lines.append(f"{icon} {label}{suffix}")
         
-        embed.set_footer(text="Meeting data has been saved and locked.")
+        embed = discord.Embed(
+            title="🔐 Permission Check",
+            description="\n".join(lines),
+            color=0xff6b6b if missing_required else 0x00ff00
+        )
+        if missing_required:
+            embed.add_field(name="Missing required permissions", value=", ".join(missing_required), inline=False)
+        else:
+            embed.set_footer(text="The bot has everything it needs in this channel.")
         
-        await interaction.response.send_message(embed=embed)
+        await reply(interaction, embed=embed, ephemeral=True)
         
     except Exception as e:
-        print(f"Error closing meeting: {e}")
-        await interaction.response.send_message("❌ Failed to close meeting. Please try again.", ephemeral=True)
+        log(f"Error checking permissions: {e}")
+        await reply(interaction, "❌ Failed to check permissions. Please try again.", ephemeral=True)
+
+
+def build_update_tips_embed() -> discord.Embed:
+    """Build the one-time embed explaining how to write a good update."""
+    embed = discord.Embed(
+        title="💡 Tips for a Great Update",
+        description="Keep it short and specific so your team can skim it in seconds.",
+        color=0x3b82f6
+    )
+    embed.add_field(name="Progress", value="What you finished since the last meeting, not everything you touched.", inline=False)
+    embed.add_field(name="Blockers", value="Anything you need help with. Write \"None\" if you're unblocked.", inline=False)
+    embed.add_field(name="Goals", value="One to three concrete things you plan to get done next.", inline=False)
+    embed.set_footer(text="You'll only see these tips once.")
+    return embed
+
+
+class RateMeetingButton(discord.ui.DynamicItem[discord.ui.Button], template=r'meetingbot:rate:(?P<meeting_id>[\w-]+)'):
+    """Button that lets an attendee rate a closed meeting."""
+    
+    def __init__(self, meeting_id: str):
+        super().__init__(
+            discord.ui.Button(
+                label="Rate this meeting",
+                emoji="⭐",
+                style=discord.ButtonStyle.secondary,
+                custom_id=f"meetingbot:rate:{meeting_id}"
+            )
+        )
+        self.meeting_id = meeting_id
+    
+    @classmethod
+    async def from_custom_id(cls, interaction: discord.Interaction, item: discord.ui.Button, match):
+        return cls(match['meeting_id'])
+    
+    async def callback(self, interaction: discord.Interaction):
+        """Privately offer the clicker a 1-5 rating, showing their earlier one if any."""
+        start_request()
+        try:
+            meeting = await call_storage(bot.storage.get_meeting, self.meeting_id)
+            user = str(interaction.user)
+            if not meeting.is_closed:
+                await reply(interaction, "❌ Meetings can be rated once they're closed.", ephemeral=True)
+                return
+            if not meeting.is_attendee(user):
+                await reply(interaction, "❌ Only the meeting's hosts and participants can rate it.", ephemeral=True)
+                return
+            
+            existing = meeting.find_rating(user)
+            prompt = f"How was **{sanitize_for_embed(meeting.name)}**?"
+            if existing:
+                prompt += f" You rated it {'⭐' * existing.score}; picking again replaces that."
+            await reply(interaction, prompt, view=RatingView(self.meeting_id, existing), ephemeral=True)
+            
+        except MeetingNotFoundError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` not found.", ephemeral=True)
+        except StorageUnavailableError:
+            await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+        except Exception as e:
+            log(f"Error opening rating for meeting {self.meeting_id}: {e}")
+            await reply(interaction, "❌ Failed to open the rating. Please try again.", ephemeral=True)
+
+
+class RatingView(ExpiringView):
+    """Score picker shown after clicking Rate this meeting."""
+    
+    def __init__(self, meeting_id: str, existing: Optional[Rating] = None):
+        super().__init__(timeout=300)
+        self.meeting_id = meeting_id
+        self.existing = existing
+    
+    @discord.ui.select(
+        placeholder="Pick a rating",
+        options=[discord.SelectOption(label="⭐" * score, value=str(score)) for score in range(5, 0, -1)]
+    )
+    async def pick_score(self, interaction: discord.Interaction, select: discord.ui.Select):
+        """Open the optional comment form for the picked score."""
+        comment = self.existing.comment if self.existing else ""
+        await interaction.response.send_modal(RatingCommentModal(self.meeting_id, int(select.values[0]), comment))
+
+
+class RatingCommentModal(discord.ui.Modal, title="Rate Meeting"):
+    """Optional comment to go with a meeting rating."""
+    
+    def __init__(self, meeting_id: str, score: int, default_comment: str = ""):
+        super().__init__()
+        self.meeting_id = meeting_id
+        self.score = score
+        self.title = f"Rate Meeting: {'⭐' * score}"
+        self.comment.default = default_comment[:self.comment.max_length]
+    
+    comment = discord.ui.TextInput(
+        label="Comment (optional)",
+        placeholder="Anything the hosts should know?",
+        style=discord.TextStyle.paragraph,
+        max_length=500,
+        required=False
+    )
+    
+    async def on_submit(self, interaction: discord.Interaction):
+        """Save the rating, replacing the user's earlier one."""
+        start_request()
+        try:
+            meeting = await call_storage(bot.storage.get_meeting, self.meeting_id)
+            user = str(interaction.user)
+            if not meeting.is_attendee(user):
+                await reply(interaction, "❌ Only the meeting's hosts and participants can rate it.", ephemeral=True)
+                return
+            
+            _, replaced = meeting.rate(user, self.score, self.comment.value)
+            await call_storage(bot.storage.save_meeting, meeting)
+            await record_audit(self.meeting_id, "rating-edit" if replaced else "rating", interaction)
+            
+            verb = "updated" if replaced else "saved"
+            await reply(interaction, f"⭐ Thanks! Your {'⭐' * self.score} rating of **{sanitize_for_embed(meeting.name)}** was {verb}.", ephemeral=True)
+            
+        except MeetingNotFoundError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` not found.", ephemeral=True)
+        except ValueError as e:
+            await reply(interaction, f"❌ {e}.", ephemeral=True)
+        except StorageUnavailableError:
+            await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+        except Exception as e:
+            log(f"Error saving rating for meeting {self.meeting_id}: {e}")
+            await reply(interaction, "❌ Failed to save your rating. Please try again.", ephemeral=True)
+
+
+class UpdateTipsView(ExpiringView):
+    """Follow-up to the tips embed that opens the update form."""
+    
+    def __init__(self, meeting_id: str, optional_fields: List[str]):
+        super().__init__(timeout=600)
+        self.meeting_id = meeting_id
+        self.optional_fields = optional_fields
+    
+    @discord.ui.button(label="Open update form", style=discord.ButtonStyle.primary)
+    async def open_form(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Open the update modal for the meeting."""
+        await interaction.response.send_modal(UpdateModal(self.meeting_id, optional_fields=self.optional_fields))
+    
+    @discord.ui.button(label="Cancel", style=discord.ButtonStyle.secondary)
+    async def cancel(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Abandon the update and remove the buttons."""
+        self.stop()
+        await interaction.response.edit_message(content="Cancelled.", embed=None, view=None)
+
+
+class ClearUpdatesView(ExpiringView):
+    """Confirmation for /meetingbot-manage clear-updates."""
+    
+    def __init__(self, meeting_id: str):
+        super().__init__(timeout=120)
+        self.meeting_id = meeting_id
+    
+    @discord.ui.button(label="Clear updates", style=discord.ButtonStyle.danger)
+    async def confirm(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Delete the meeting's updates, keeping the meeting itself."""
+        start_request()
+        self.stop()
+        try:
+            # Reload so updates submitted while the prompt was open are cleared too
+            meeting = await call_storage(bot.storage.get_open_meeting, self.meeting_id)
+            if meeting.guild_id != interaction.guild_id:
+                raise MeetingNotFoundError(self.meeting_id)
+            count = meeting.clear_updates()
+            await call_storage(bot.storage.save_meeting, meeting)
+            await record_audit(self.meeting_id, "clear-updates", interaction)
+            await interaction.response.edit_message(
+                content=f"🧹 Cleared {count} updates from meeting `{self.meeting_id}`.",
+                view=None
+            )
+            await refresh_meeting_card(meeting)
+        except MeetingNotFoundError:
+            await interaction.response.edit_message(content=f"❌ Meeting `{self.meeting_id}` not found.", view=None)
+        except MeetingClosedError:
+            await interaction.response.edit_message(content=f"❌ Meeting `{self.meeting_id}` was closed; its updates were kept.", view=None)
+        except StorageUnavailableError:
+            await interaction.response.edit_message(content=STORAGE_UNAVAILABLE_MESSAGE, view=None)
+        except Exception as e:
+            log(f"Error clearing updates: {e}")
+            await interaction.response.edit_message(content="❌ Failed to clear updates. Please try again.", view=None)
+    
+    @discord.ui.button(label="Cancel", style=discord.ButtonStyle.secondary)
+    async def cancel(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Keep the updates and remove the buttons."""
+        self.stop()
+        await interaction.response.edit_message(content="Cancelled; no updates were cleared.", view=None)
+
+
+class MergeMeetingsView(ExpiringView):
+    """Confirmation for /meetingbot-manage merge."""
+    
+    def __init__(self, source_id: str, target_id: str):
+        super().__init__(timeout=120)
+        self.source_id = source_id
+        self.target_id = target_id
+    
+    @discord.ui.button(label="Merge", style=discord.ButtonStyle.danger)
+    async def confirm(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Merge the meetings and retire the source's card."""
+        start_request()
+        self.stop()
+        try:
+            source = await call_storage(bot.storage.get_meeting, self.source_id)
+            target, skipped = await call_storage(bot.storage.merge_meetings, self.source_id, self.target_id)
+            await record_audit(self.source_id, "merged-away", interaction)
+            await record_audit(self.target_id, "merge", interaction)
+            
+            message = f"🔀 Merged `{self.source_id}` into `{self.target_id}`."
+            if skipped:
+                message += f" {skipped} same-day duplicate updates were dropped in favor of `{self.target_id}`'s."
+            await interaction.response.edit_message(content=message, view=None)
+            
+            await refresh_meeting_card(target)
+            await bot.refresh_presence()
+            try:
+                card = await get_card_message(source)
+                if card:
+                    await card.delete()
+            except discord.HTTPException as e:
+                log(f"Warning: Could not delete card for merged meeting {self.source_id}: {e}")
+        except MeetingNotFoundError as e:
+            await interaction.response.edit_message(content=f"❌ Meeting `{e.args[0]}` not found.", view=None)
+        except MeetingClosedError:
+            await interaction.response.edit_message(content=f"❌ Meeting `{self.target_id}` was closed; nothing was merged.", view=None)
+        except ValueError as e:
+            await interaction.response.edit_message(content=f"❌ {e}.", view=None)
+        except StorageUnavailableError:
+            await interaction.response.edit_message(content=STORAGE_UNAVAILABLE_MESSAGE, view=None)
+        except Exception as e:
+            log(f"Error merging meetings: {e}")
+            await interaction.response.edit_message(content="❌ Failed to merge meetings. Please try again.", view=None)
+    
+    @discord.ui.button(label="Cancel", style=discord.ButtonStyle.secondary)
+    async def cancel(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Leave both meetings as they are and remove the buttons."""
+        self.stop()
+        await interaction.response.edit_message(content="Cancelled; nothing was merged.", view=None)
+
+
+class RemindAllView(ExpiringView):
+    """Confirmation for /meetingbot-manage remind-all."""
+    
+    def __init__(self):
+        super().__init__(timeout=120)
+    
+    @discord.ui.button(label="Send reminders", style=discord.ButtonStyle.danger)
+    async def confirm(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """DM each rostered member who is still missing updates, one message per member."""
+        start_request()
+        self.stop()
+        try:
+            await interaction.response.edit_message(content="⏳ Sending reminders…", view=None)
+            
+            # Recomputed so updates posted while the prompt was open aren't nagged about
+            meetings = await call_storage(bot.storage.find_meetings, interaction.guild.id)
+            missing_by_user = collect_missing_updates(meetings)
+            user_ids = {name: user_id for meeting in meetings for name, user_id in meeting.user_ids.items()}
+            
+            recipients, pending, unknown = [], {}, []
+            for name, user_meetings in missing_by_user.items():
+                member = await find_member(interaction.guild, name, user_ids.get(name))
+                if member:
+                    recipients.append(member)
+                    pending[member.id] = user_meetings
+                else:
+                    unknown.append(name)
+            
+            recipients, snoozed = await without_dnd_users(recipients)
+            failures = await send_batch_dms(recipients, lambda user: {'content': build_reminder_message(pending[user.id])})
+            for meeting_id in {meeting.id for user_meetings in pending.values() for meeting in user_meetings}:
+                await record_audit(meeting_id, "remind-all", interaction)
+            
+            lines = [f"⏰ Sent {len(recipients) - len(failures)} reminder(s)."]
+            if failures:
+                lines.append(f"Couldn't DM: {sanitize_list(str(user) for user, _ in failures)}")
+            if snoozed:
+                lines.append(f"Snoozed DMs: {sanitize_list(str(user) for user in snoozed)}")
+            if unknown:
+                lines.append(f"No longer in this server: {sanitize_list(unknown)}")
+            await interaction.edit_original_response(content="\n".join(lines))
+        except StorageUnavailableError:
+            await interaction.edit_original_response(content=STORAGE_UNAVAILABLE_MESSAGE)
+        except Exception as e:
+            log(f"Error sending reminders: {e}")
+            await interaction.edit_original_response(content="❌ Failed to send reminders. Please try again.")
+    
+    @discord.ui.button(label="Cancel", style=discord.ButtonStyle.secondary)
+    async def cancel(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Send nothing and remove the buttons."""
+        self.stop()
+        await interaction.response.edit_message(content="Cancelled; no reminders were sent.", view=None)
 
 
 class UpdateModal(discord.ui.Modal, title="Meeting Update"):
     """Modal form for submitting meeting updates."""
     
-    def __init__(self, meeting_id: str):
+    def __init__(self, meeting_id: str, existing: Optional[Update] = None, optional_fields: List[str] = ()):
         super().__init__()
         self.meeting_id = meeting_id
+        self.progress.max_length = get_max_length('UPDATE_PROGRESS')
+        self.blockers.max_length = get_max_length('UPDATE_BLOCKERS')
+        self.goals.max_length = get_max_length('UPDATE_GOALS')
+        # Servers can make questions optional with /meetingbot-manage standup-fields
+        self.required_fields = [name for name in UPDATE_FIELDS if name not in optional_fields]
+        for name in UPDATE_FIELDS:
+            text_input = getattr(self, name)
+            text_input.required = name in self.required_fields
+            if not text_input.required:
+                text_input.label = f"{name.capitalize()} (optional)"
+        if existing:
+            self.title = "Edit Today's Update"
+            self.progress.default = existing.progress[:self.progress.max_length]
+            self.blockers.default = existing.blockers[:self.blockers.max_length]
+            self.goals.default = existing.goals[:self.goals.max_length]
     
     progress = discord.ui.TextInput(
         label="Progress",
@@ -237,41 +3434,82 @@ class UpdateModal(discord.ui.Modal, title="Meeting Update"):
     
     async def on_submit(self, interaction: discord.Interaction):
         """Handle form submission."""
+        start_request()
+        log(f"Update modal submitted by {interaction.user} (meeting: {self.meeting_id})")
         try:
-            meeting = bot.storage.load_meeting(self.meeting_id)
-            meeting.add_update(
+            meeting = await call_storage(bot.storage.get_open_meeting, self.meeting_id)
+            update, replaced = meeting.add_update(
                 user=str(interaction.user),
                 progress=self.progress.value.strip(),
                 blockers=self.blockers.value.strip(),
-                goals=self.goals.value.strip()
+                goals=self.goals.value.strip(),
+                required=self.required_fields
             )
+            meeting.remember_user(str(interaction.user), interaction.user.id)
             
-            bot.storage.save_meeting(meeting)
+            await call_storage(bot.storage.save_meeting, meeting)
+            await record_audit(self.meeting_id, "update-edit" if replaced else "update", interaction, anonymous=meeting.anonymous_updates)
             
-            embed = discord.Embed(
-                title="✅ Update Added",
-                description=f"Your update has been added to meeting `{self.meeting_id}`",
-                color=0x00ff00
-            )
-            embed.add_field(name="Progress", value=self.progress.value[:1000], inline=False)
-            embed.add_field(name="Blockers", value=self.blockers.value[:1000], inline=False)
-            embed.add_field(name="Goals", value=self.goals.value[:1000], inline=False)
+            if replaced:
+                title, description = "✅ Update Replaced", f"Your update from earlier today in meeting `{self.meeting_id}` has been replaced"
+            else:
+                title, description = "✅ Update Added", f"Your update has been added to meeting `{self.meeting_id}`"
+            embed = discord.Embed(title=title, description=description, color=0x00ff00)
+            embed.add_field(name="Progress", value=sanitize_for_embed(self.progress.value[:900]) or "—", inline=False)
+            embed.add_field(name="Blockers", value=sanitize_for_embed(self.blockers.value[:900]) or "—", inline=False)
+            embed.add_field(name="Goals", value=sanitize_for_embed(self.goals.value[:900]) or "—", inline=False)
             embed.add_field(name="Total Updates", value=str(len(meeting.updates)), inline=True)
             embed.add_field(name="Updated by", value=interaction.user.mention, inline=True)
             
-            await interaction.response.send_message(embed=embed, ephemeral=True)
+            await reply(interaction, embed=embed, ephemeral=True)
+            await refresh_meeting_card(meeting)
+            await post_update_to_thread(meeting, update, replaced)
             
+        except MeetingNotFoundError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` not found.", ephemeral=True)
+        except MeetingClosedError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` was closed before your update was submitted.", ephemeral=True)
         except ValueError as e:
-            await interaction.response.send_message(f"❌ Validation error: {str(e)}", ephemeral=True)
+            await reply(interaction, f"❌ Validation error: {str(e)}", ephemeral=True)
+        except StorageUnavailableError:
+            await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
         except Exception as e:
-            print(f"Error submitting update: {e}")
-            await interaction.response.send_message("❌ Failed to submit update. Please try again.", ephemeral=True)
+            log(f"Error submitting update: {e}")
+            await reply(interaction, "❌ Failed to submit update. Please try again.", ephemeral=True)
+
+def screen_meeting_text(guild_id: Optional[int], name: str, location: str, tags: List[str]) -> Optional[Tuple[str, str, List[str]]]:
+    """
+    Apply the guild's content filter to text shown on public meeting cards.
+    
+    Returns:
+        The (possibly masked) name, location, and tags, or None if the filter rejects them
+    """
+    content_filter = bot.content_filter
+    if not (content_filter and content_filter.applies_to(guild_id)):
+        return name, location, tags
+    if content_filter.mode == MASK:
+        return content_filter.mask(name), content_filter.mask(location), [content_filter.mask(tag) for tag in tags]
+    if content_filter.find(" ".join([name, location, *tags])):
+        return None
+    return name, location, tags
+
 
 class CreateMeetingModal(discord.ui.Modal, title="Create Meeting"):
     """Modal form for creating a new meeting."""
     
-    def __init__(self):
+    def __init__(self, default_name: str = "", default_link: str = "", default_location: str = "", default_tags: str = "", visibility: str = PUBLIC, anonymous_updates: bool = False):
         super().__init__()
+        self.visibility = visibility
+        self.anonymous_updates = anonymous_updates
+        self.name.max_length = get_max_length('MEETING_NAME')
+        self.link.max_length = get_max_length('MEETING_LINK')
+        self.location.max_length = get_max_length('MEETING_LOCATION')
+        # Pre-fill fields, e.g. when duplicating an existing meeting
+        self.name.default = default_name[:self.name.max_length]
+        self.link.default = default_link[:self.link.max_length]
+        self.location.default = default_location[:self.location.max_length]
+        self.tags.max_length = get_max_length('MEETING_TAGS')
+        self.tags.default = default_tags[:self.tags.max_length]
     
     name = discord.ui.TextInput(
         label="Name",
@@ -289,40 +3527,199 @@ class CreateMeetingModal(discord.ui.Modal, title="Create Meeting"):
         required=False
     )
     
+    location = discord.ui.TextInput(
+        label="Location",
+        placeholder="Where is the meeting held in person? (room, address)",
+        style=discord.TextStyle.short,
+        max_length=200,
+        required=False
+    )
+    
+    tags = discord.ui.TextInput(
+        label="Tags",
+        placeholder="Comma-separated, e.g. standup, frontend",
+        style=discord.TextStyle.short,
+        max_length=100,
+        required=False
+    )
+    
+    async def on_submit(self, interaction: discord.Interaction):
+        """Handle form submission."""
+        start_request()
+        log(f"Create meeting modal submitted by {interaction.user}")
+        try:
+            name = self.name.value.strip() if self.name.value else ""
+            link = self.link.value.strip() if self.link.value else ""
+            location = self.location.value.strip() if self.location.value else ""
+            tags = parse_tags(self.tags.value) if self.tags.value else []
+            
+            screened = screen_meeting_text(interaction.guild_id, name, location, tags)
+            if screened is None:
+                log(f"Blocked meeting submission from {interaction.user} by content filter")
+                await reply(interaction, BLOCKED_CONTENT_MESSAGE, ephemeral=True)
+                return
+            name, location, tags = screened
+            
+            # Only public meetings are announced, so only they need approval
+            approver_role_id = None
+            if interaction.guild_id and self.visibility == PUBLIC:
+                settings = await call_storage(bot.storage.load_guild_settings, interaction.guild_id)
+                approver_role_id = settings.approver_role_id
+            
+            meeting = Meeting.create_new(
+                created_by=str(interaction.user),
+                name=name,
+                link=link,
+                location=location,
+                tags=tags,
+                visibility=self.visibility,
+                anonymous_updates=self.anonymous_updates
+            )
+            meeting.remember_user(str(interaction.user), interaction.user.id)
+            # Remember where it was created so lookups stay within this server,
+            # and so the card is posted there once approved
+            meeting.guild_id = interaction.guild_id
+            meeting.channel_id = interaction.channel_id
+            if approver_role_id:
+                meeting.approval = PENDING
+            await call_storage(bot.storage.create_meeting, meeting)
+            await record_audit(meeting.id, "create", interaction)
+            
+            if approver_role_id:
+                await request_approval(interaction, meeting, approver_role_id)
+                return
+            await bot.refresh_presence()
+            
+            embed = build_meeting_card_embed(meeting, interaction.user.mention, interaction.created_at)
+            
+            # Private meetings aren't announced to the whole channel
+            if meeting.visibility == PRIVATE:
+                await reply(
+                    interaction,
+                    f"🔐 Private meeting `{meeting.id}` created. Share the ID with the people who should join.",
+                    embed=embed,
+                    ephemeral=True
+                )
+                return
+            
+            await reply(
+                interaction,
+                f"✅ Meeting `{meeting.id}` created. The meeting card has been posted to the channel.",
+                ephemeral=True
+            )
+            
+            # Post the public card separately so the confirmation above stays private
+            await post_meeting_card(interaction, meeting, embed, mention=True)
+        except DuplicateMeetingError:
+            # Random ID suffixes make this very unlikely; submitting again picks a new ID
+            await reply(interaction, "❌ That meeting ID was just taken. Please submit again.", ephemeral=True)
+        except StorageUnavailableError:
+            await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
+        except Exception as e:
+            log(f"Error creating meeting: {e}")
+            await reply(interaction, "❌ Failed to create meeting. Please try again.", ephemeral=True)
+
+
+def edited_fields(meeting: Meeting) -> tuple:
+    """The meeting details the edit form changes, for spotting edits made by someone else."""
+    return (meeting.name, meeting.link or "", meeting.location, tuple(meeting.tags))
+
+
+class EditMeetingModal(CreateMeetingModal, title="Edit Meeting"):
+    """Modal form for editing an open meeting's details, pre-filled with the current values."""
+    
+    def __init__(self, meeting: Meeting):
+        super().__init__(
+            default_name=meeting.name if meeting.name != meeting.id else "",
+            default_link=meeting.link or "",
+            default_location=meeting.location,
+            default_tags=", ".join(meeting.tags)
+        )
+        self.meeting_id = meeting.id
+        # Edits are based on what the form showed, so they're refused if those fields
+        # changed meanwhile; updates, ratings and other saves don't conflict
+        self.shown_fields = edited_fields(meeting)
+    
     async def on_submit(self, interaction: discord.Interaction):
         """Handle form submission."""
+        start_request()
+        log(f"Edit meeting modal submitted by {interaction.user} (meeting: {self.meeting_id})")
         try:
             name = self.name.value.strip() if self.name.value else ""
             link = self.link.value.strip() if self.link.value else ""
-            meeting = Meeting.create_new(created_by=str(interaction.user), name=name, link=link)
-            bot.storage.save_meeting(meeting)
+            location = self.location.value.strip() if self.location.value else ""
+            tags = parse_tags(self.tags.value) if self.tags.value else []
+            
+            screened = screen_meeting_text(interaction.guild_id, name, location, tags)
+            if screened is None:
+                log(f"Blocked meeting edit from {interaction.user} by content filter")
+                await reply(interaction, BLOCKED_CONTENT_MESSAGE, ephemeral=True)
+                return
+            name, location, tags = screened
+            
+            # Reload so the diff is against what's stored now, not when the form opened
+            meeting = await call_storage(bot.storage.get_open_meeting, self.meeting_id)
+            if not meeting.is_host(str(interaction.user)):
+                await reply(interaction, "❌ Only the meeting's creator or co-hosts can do that.", ephemeral=True)
+                return
             
-            embed = discord.Embed(
-                title="✅ New Meeting Created",
-                description=f"Meeting ID: `{meeting.id}`",
-                color=0x00ff00
+            if edited_fields(meeting) != self.shown_fields:
+                raise ConcurrentModificationError(self.meeting_id)
+            
+            loaded_version = meeting.version
+            changes = meeting.apply_edits(name, link, location, tags)
+            if not changes:
+                await reply(interaction, f"Nothing changed in meeting `{self.meeting_id}`.", ephemeral=True)
+                return
+            
+            await call_storage(bot.storage.save_meeting, meeting, loaded_version)
+            change = MeetingChange(
+                meeting_id=meeting.id,
+                changed_by=str(interaction.user),
+                changed_at=datetime.now().isoformat(),
+                changes=changes
             )
-            embed.add_field(name="Created by", value=interaction.user.mention, inline=True)
-            embed.add_field(name="Created at", value=f"<t:{int(interaction.created_at.timestamp())}:F>", inline=True)
-            embed.add_field(name="Updates", value="0", inline=True)
+            await call_storage(bot.storage.append_meeting_change, change)
+            await record_audit(meeting.id, "edit", interaction)
             
-            embed.set_footer(text="Use /meetingbot update <meeting_id> to add updates")
+            await reply(
+                interaction,
+                f"✅ Meeting `{meeting.id}` updated:\n{format_field_changes(change)}",
+                ephemeral=True
+            )
+            await refresh_meeting_card(meeting)
             
-            await interaction.response.send_message(embed=embed)
+        except MeetingNotFoundError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` not found.", ephemeral=True)
+        except MeetingClosedError:
+            await reply(interaction, f"❌ Meeting `{self.meeting_id}` is no longer open and can't be edited.", ephemeral=True)
+        except ConcurrentModificationError:
+            await reply(
+                interaction,
+                f"❌ Meeting `{self.meeting_id}` changed while you were editing. Run `/meetingbot edit` again to see the latest version.",
+                ephemeral=True
+            )
+        except StorageUnavailableError:
+            await reply(interaction, STORAGE_UNAVAILABLE_MESSAGE, ephemeral=True)
         except Exception as e:
-            print(f"Error creating meeting: {e}")
-            await interaction.response.send_message("❌ Failed to create meeting. Please try again.", ephemeral=True)
+            log(f"Error editing meeting: {e}")
+            await reply(interaction, "❌ Failed to edit meeting. Please try again.", ephemeral=True)
+
 
 def main():
     """Main function to run the bot."""
     load_dotenv()
     
-    token = os.getenv('DISCORD_TOKEN')
-    if not token:
-        print("❌ DISCORD_TOKEN not found in environment variables!")
-        print("Please create a .env file with your Discord bot token.")
+    problems = validate_env()
+    if problems:
+        print("❌ Invalid configuration:")
+        for problem in problems:
+            print(f"  - {problem}")
+        print("Please fix your .env file (see example.env).")
         return
     
+    token = os.getenv('DISCORD_TOKEN')
+    
     try:
         bot.run(token)
     except discord.LoginFailure:
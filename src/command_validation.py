@@ -0,0 +1,82 @@
+"""
+Validation of application commands against Discord's registration limits.
+"""
+from typing import Sequence, Union
+
+import discord
+from discord import app_commands
+
+# Limits from https://discord.com/developers/docs/interactions/application-commands
+MAX_SLASH_COMMANDS = 100
+MAX_CONTEXT_MENUS_PER_TYPE = 5
+MAX_OPTIONS = 25
+MAX_CHOICES = 25
+MAX_NAME_LENGTH = 32
+MAX_DESCRIPTION_LENGTH = 100
+MAX_CHOICE_NAME_LENGTH = 100
+
+AnyCommand = Union[app_commands.Command, app_commands.Group, app_commands.ContextMenu]
+
+
+class CommandLimitError(ValueError):
+    """Raised when a command definition exceeds one of Discord's limits."""
+
+
+def _check_name_and_description(path: str, name: str, description: str):
+    """Check the name and description lengths of a command, group, or option."""
+    if not 1 <= len(name) <= MAX_NAME_LENGTH:
+        raise CommandLimitError(f"{path}: name must be 1-{MAX_NAME_LENGTH} characters (got {len(name)})")
+    if not 1 <= len(description) <= MAX_DESCRIPTION_LENGTH:
+        raise CommandLimitError(f"{path}: description must be 1-{MAX_DESCRIPTION_LENGTH} characters (got {len(description)})")
+
+
+def _validate_command(command: Union[app_commands.Command, app_commands.Group], path: str):
+    """Recursively validate a slash command or group."""
+    _check_name_and_description(path, command.name, command.description)
+
+    if isinstance(command, app_commands.Group):
+        if len(command.commands) > MAX_OPTIONS:
+            raise CommandLimitError(f"{path}: has {len(command.commands)} subcommands (max {MAX_OPTIONS})")
+        for child in command.commands:
+            _validate_command(child, f"{path} {child.name}")
+        return
+
+    if len(command.parameters) > MAX_OPTIONS:
+        raise CommandLimitError(f"{path}: has {len(command.parameters)} options (max {MAX_OPTIONS})")
+
+    for parameter in command.parameters:
+        option_path = f"{path} [{parameter.name}]"
+        _check_name_and_description(option_path, parameter.name, parameter.description)
+
+        if len(parameter.choices) > MAX_CHOICES:
+            raise CommandLimitError(f"{option_path}: has {len(parameter.choices)} choices (max {MAX_CHOICES})")
+        for choice in parameter.choices:
+            if not 1 <= len(choice.name) <= MAX_CHOICE_NAME_LENGTH:
+                raise CommandLimitError(
+                    f"{option_path}: choice '{choice.name}' name must be 1-{MAX_CHOICE_NAME_LENGTH} characters"
+                )
+
+
+def validate_commands(commands: Sequence[AnyCommand]) -> None:
+    """
+    Validate a command tree against Discord's limits before registering it.
+
+    Raises:
+        CommandLimitError: naming the first command, option, or choice that violates a limit
+    """
+    slash_commands = [c for c in commands if not isinstance(c, app_commands.ContextMenu)]
+    if len(slash_commands) > MAX_SLASH_COMMANDS:
+        raise CommandLimitError(f"{len(slash_commands)} slash commands registered (max {MAX_SLASH_COMMANDS})")
+
+    for menu_type in (discord.AppCommandType.user, discord.AppCommandType.message):
+        menus = [c for c in commands if isinstance(c, app_commands.ContextMenu) and c.type == menu_type]
+        if len(menus) > MAX_CONTEXT_MENUS_PER_TYPE:
+            raise CommandLimitError(
+                f"{len(menus)} {menu_type.name} context menus registered (max {MAX_CONTEXT_MENUS_PER_TYPE})"
+            )
+        for menu in menus:
+            if not 1 <= len(menu.name) <= MAX_NAME_LENGTH:
+                raise CommandLimitError(f"{menu.name}: context menu name must be 1-{MAX_NAME_LENGTH} characters")
+
+    for command in slash_commands:
+        _validate_command(command, f"/{command.name}")
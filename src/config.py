@@ -0,0 +1,177 @@
+"""
+Runtime settings read from environment variables.
+"""
+import os
+from datetime import time, timezone
+from pathlib import Path
+from typing import List, Tuple
+from urllib.parse import urlparse
+
+# Discord rejects text inputs with max_length outside this range
+DISCORD_TEXT_INPUT_MIN_LENGTH = 1
+DISCORD_TEXT_INPUT_MAX_LENGTH = 4000
+
+# Where close summaries go; "dm" sends them to the meeting's hosts and participants
+SUMMARY_DESTINATIONS = ("channel", "thread", "dm", "none")
+CONTENT_FILTER_MODES = ("reject", "mask")
+AWS_SETTINGS = ('AWS_ACCESS_KEY_ID', 'AWS_SECRET_ACCESS_KEY', 'AWS_S3_BUCKET')
+
+DEFAULT_DIGEST_TIMES = '09:00'
+
+DEFAULT_MAX_LENGTHS = {
+    'MEETING_NAME': 50,
+    'MEETING_LINK': 500,
+    'MEETING_LOCATION': 200,
+    'MEETING_TAGS': 100,
+    'UPDATE_PROGRESS': 500,
+    'UPDATE_BLOCKERS': 500,
+    'UPDATE_GOALS': 500,
+}
+
+
+def clamp_text_length(value: int) -> int:
+    """Clamp a text input length to the range Discord allows."""
+    return max(DISCORD_TEXT_INPUT_MIN_LENGTH, min(value, DISCORD_TEXT_INPUT_MAX_LENGTH))
+
+
+def get_max_length(field: str) -> int:
+    """
+    Get the max length for a modal field, overridable with <FIELD>_MAX_LENGTH.
+
+    Args:
+        field: A key of DEFAULT_MAX_LENGTHS, e.g. 'UPDATE_PROGRESS'
+
+    Returns:
+        int: The configured length clamped to Discord's limits
+    """
+    default = DEFAULT_MAX_LENGTHS[field]
+    raw = os.getenv(f'{field}_MAX_LENGTH', '').strip()
+    if not raw:
+        return default
+
+    try:
+        return clamp_text_length(int(raw))
+    except ValueError:
+        print(f"Warning: {field}_MAX_LENGTH is not a valid integer; using {default}")
+        return default
+
+
+def get_bool(name: str, default: bool = False) -> bool:
+    """Read a true/false environment variable."""
+    raw = os.getenv(name, '').strip().lower()
+    if not raw:
+        return default
+    return raw in ('1', 'true', 'yes', 'on')
+
+
+def get_choice(name: str, choices: Tuple[str, ...], default: str) -> str:
+    """Read an environment variable that must be one of a fixed set of values."""
+    raw = os.getenv(name, '').strip().lower()
+    if not raw:
+        return default
+    if raw not in choices:
+        print(f"Warning: {name} must be one of {', '.join(choices)}; using {default}")
+        return default
+    return raw
+
+
+def parse_times(raw: str) -> List[time]:
+    """
+    Parse comma-separated HH:MM times of day, in UTC.
+
+    Raises:
+        ValueError: If any entry isn't a valid HH:MM time
+    """
+    times = []
+    for part in raw.split(','):
+        if not part.strip():
+            continue
+        hour, sep, minute = part.strip().partition(':')
+        if not (sep and hour.isdigit() and minute.isdigit()):
+            raise ValueError(f"expected HH:MM, got {part.strip()!r}")
+        times.append(time(int(hour), int(minute), tzinfo=timezone.utc))
+    if not times:
+        raise ValueError("no times given")
+    return times
+
+
+def is_valid_url(url: str) -> bool:
+    """Check that a string is an absolute http(s) URL."""
+    parsed = urlparse(url)
+    return parsed.scheme in ("http", "https") and bool(parsed.netloc)
+
+
+def _check_ids(name: str, problems: List[str]):
+    """Record a problem if a comma-separated ID setting has non-integer entries."""
+    for raw in os.getenv(name, '').split(','):
+        if raw.strip() and not raw.strip().isdigit():
+            problems.append(f"{name} has an invalid ID: {raw.strip()!r}")
+
+
+def _check_choice(name: str, choices: Tuple[str, ...], problems: List[str]):
+    """Record a problem if a setting is set to something outside its choices."""
+    raw = os.getenv(name, '').strip().lower()
+    if raw and raw not in choices:
+        problems.append(f"{name} must be one of {', '.join(choices)}; got {raw!r}")
+
+
+def validate_env() -> List[str]:
+    """
+    Check every setting up front so a bad .env is reported all at once
+    instead of one warning at a time as each feature is first used.
+
+    Returns:
+        List[str]: One line per missing or malformed setting; empty if all is well
+    """
+    problems = []
+
+    if not os.getenv('DISCORD_TOKEN', '').strip():
+        problems.append("DISCORD_TOKEN is required")
+
+    _check_ids('DISCORD_GUILD_IDS', problems)
+    _check_ids('DISCORD_GUILD_ID', problems)
+    _check_ids('CONTENT_FILTER_GUILD_IDS', problems)
+
+    _check_ids('DIGEST_CHANNEL_ID', problems)
+    try:
+        parse_times(os.getenv('DIGEST_TIMES', '') or DEFAULT_DIGEST_TIMES)
+    except ValueError as e:
+        problems.append(f"DIGEST_TIMES must be comma-separated HH:MM times; {e}")
+
+    default_link = os.getenv('DEFAULT_MEETING_LINK', '').strip()
+    if default_link and not is_valid_url(default_link):
+        problems.append("DEFAULT_MEETING_LINK must be an http(s) URL")
+
+    for field in DEFAULT_MAX_LENGTHS:
+        raw = os.getenv(f'{field}_MAX_LENGTH', '').strip()
+        if not raw:
+            continue
+        try:
+            value = int(raw)
+        except ValueError:
+            problems.append(f"{field}_MAX_LENGTH must be an integer; got {raw!r}")
+            continue
+        if value != clamp_text_length(value):
+            problems.append(
+                f"{field}_MAX_LENGTH must be between {DISCORD_TEXT_INPUT_MIN_LENGTH} "
+                f"and {DISCORD_TEXT_INPUT_MAX_LENGTH}; got {value}"
+            )
+
+    _check_choice('SUMMARY_DESTINATION', SUMMARY_DESTINATIONS, problems)
+    _check_choice('CONTENT_FILTER_MODE', CONTENT_FILTER_MODES, problems)
+
+    mention = os.getenv('CREATE_MENTION', '').strip().lower()
+    if mention and mention not in ('here', 'everyone') and not mention.isdigit():
+        problems.append(f"CREATE_MENTION must be a role ID, here, or everyone; got {mention!r}")
+
+    word_file = os.getenv('CONTENT_FILTER_FILE', '').strip()
+    if word_file and not Path(word_file).is_file():
+        problems.append(f"CONTENT_FILTER_FILE does not exist: {word_file}")
+
+    # S3 is optional, but half a set of credentials is almost always a mistake
+    aws_set = [name for name in AWS_SETTINGS if os.getenv(name, '').strip()]
+    if aws_set and len(aws_set) < len(AWS_SETTINGS):
+        missing = [name for name in AWS_SETTINGS if name not in aws_set]
+        problems.append(f"S3 is partly configured; also set {', '.join(missing)}")
+
+    return problems
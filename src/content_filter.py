@@ -0,0 +1,75 @@
+"""
+Configurable word filter for user-submitted meeting text.
+"""
+import os
+import re
+from pathlib import Path
+from typing import Iterable, List, Optional
+
+REJECT = 'reject'
+MASK = 'mask'
+
+
+class ContentFilter:
+    """Matches blocked words (whole words, case-insensitive) with a single compiled pattern."""
+
+    def __init__(self, words: Iterable[str], mode: str = REJECT, guild_ids: Iterable[int] = ()):
+        words = sorted({w.strip().lower() for w in words if w.strip()}, key=len, reverse=True)
+        self.mode = mode if mode in (REJECT, MASK) else REJECT
+        self.guild_ids = set(guild_ids)
+        self.pattern = re.compile(
+            r'\b(' + '|'.join(re.escape(w) for w in words) + r')\b',
+            re.IGNORECASE
+        ) if words else None
+
+    def applies_to(self, guild_id: Optional[int]) -> bool:
+        """Check whether a guild has opted in to filtering."""
+        return guild_id in self.guild_ids
+
+    def find(self, text: str) -> List[str]:
+        """Return blocked words found in the text."""
+        if not self.pattern or not text:
+            return []
+        return [match.group(0) for match in self.pattern.finditer(text)]
+
+    def mask(self, text: str) -> str:
+        """Replace each blocked word with asterisks of the same length."""
+        if not self.pattern or not text:
+            return text
+        return self.pattern.sub(lambda match: '*' * len(match.group(0)), text)
+
+    @classmethod
+    def from_env(cls) -> Optional['ContentFilter']:
+        """
+        Build a filter from CONTENT_FILTER_WORDS (comma-separated) and/or
+        CONTENT_FILTER_FILE (one word per line), using CONTENT_FILTER_MODE.
+        Only guilds listed in CONTENT_FILTER_GUILD_IDS are filtered.
+
+        Returns:
+            ContentFilter, or None if no words or guilds are configured
+        """
+        guild_ids = []
+        for raw in os.getenv('CONTENT_FILTER_GUILD_IDS', '').split(','):
+            if not raw.strip():
+                continue
+            try:
+                guild_ids.append(int(raw.strip()))
+            except ValueError:
+                print(f"Warning: Ignoring invalid guild ID in CONTENT_FILTER_GUILD_IDS: {raw.strip()}")
+        if not guild_ids:
+            return None
+
+        words = [w for w in os.getenv('CONTENT_FILTER_WORDS', '').split(',') if w.strip()]
+
+        word_file = os.getenv('CONTENT_FILTER_FILE', '').strip()
+        if word_file:
+            try:
+                words += Path(word_file).read_text(encoding='utf-8').splitlines()
+            except OSError as e:
+                print(f"Warning: Could not read CONTENT_FILTER_FILE {word_file}: {e}")
+
+        if not any(w.strip() for w in words):
+            return None
+
+        mode = os.getenv('CONTENT_FILTER_MODE', REJECT).strip().lower()
+        return cls(words, mode, guild_ids)
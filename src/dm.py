@@ -0,0 +1,64 @@
+"""
+Rate-limited batch sending of direct messages.
+"""
+import asyncio
+import time
+from typing import Any, Callable, Dict, Iterable, List, Tuple
+
+import discord
+
+# Opening many DM channels quickly gets bots flagged, so sends are paced globally
+DM_MIN_INTERVAL_SECONDS = 0.5
+DM_CONCURRENCY = 4
+
+
+class _RateLimiter:
+    """Spaces out sends across every batch in the process."""
+
+    def __init__(self, min_interval: float):
+        self.min_interval = min_interval
+        self._next_send = 0.0
+        self._lock = asyncio.Lock()
+
+    async def wait(self):
+        """Wait until the next send slot is free, then claim it."""
+        async with self._lock:
+            delay = self._next_send - time.monotonic()
+            if delay > 0:
+                await asyncio.sleep(delay)
+            self._next_send = time.monotonic() + self.min_interval
+
+
+_limiter = _RateLimiter(DM_MIN_INTERVAL_SECONDS)
+
+
+async def send_batch_dms(
+    users: Iterable[discord.abc.User],
+    build_message: Callable[[discord.abc.User], Dict[str, Any]],
+    concurrency: int = DM_CONCURRENCY
+) -> List[Tuple[discord.abc.User, discord.HTTPException]]:
+    """
+    DM each user, with at most `concurrency` sends in flight and a global rate limit.
+
+    Args:
+        users: Recipients
+        build_message: Returns the keyword arguments for `user.send` for one recipient
+            (called per user so files can be rebuilt for each send)
+        concurrency: Maximum concurrent sends for this batch
+
+    Returns:
+        (user, error) for every DM that failed, e.g. because the user blocks DMs
+    """
+    semaphore = asyncio.Semaphore(concurrency)
+    failures: List[Tuple[discord.abc.User, discord.HTTPException]] = []
+
+    async def send(user: discord.abc.User):
+        async with semaphore:
+            await _limiter.wait()
+            try:
+                await user.send(**build_message(user))
+            except discord.HTTPException as e:
+                failures.append((user, e))
+
+    await asyncio.gather(*(send(user) for user in users))
+    return failures
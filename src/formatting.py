@@ -0,0 +1,32 @@
+"""
+Escaping user-supplied text before it is shown in Discord messages and embeds.
+"""
+from typing import Iterable
+
+import discord
+
+
+def sanitize_for_embed(text: str) -> str:
+    """
+    Make user text render literally: Markdown control characters are escaped and
+    mentions (including @everyone and @here) are broken so they can't ping.
+
+    Links are left intact so they stay clickable.
+    """
+    return discord.utils.escape_mentions(discord.utils.escape_markdown(text or ""))
+
+
+def sanitize_list(values: Iterable[str]) -> str:
+    """Sanitize and comma-join user values such as tags or member names."""
+    return ", ".join(sanitize_for_embed(value) for value in values)
+
+
+def sanitize_code(text: str) -> str:
+    """Make user text safe inside an inline code span, where escapes aren't rendered."""
+    return (text or "").replace("`", "'")
+
+
+def truncate(text: str, limit: int) -> str:
+    """Shorten text to at most `limit` characters, marking the cut with an ellipsis."""
+    text = text or ""
+    return text if len(text) <= limit else text[:limit - 1] + "…"
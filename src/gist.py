@@ -0,0 +1,60 @@
+"""
+Publishing meeting minutes to GitHub Gists.
+"""
+import os
+from typing import Optional
+
+import aiohttp
+
+GIST_API_URL = "https://api.github.com/gists"
+GIST_TIMEOUT_SECONDS = 15
+
+
+class GistError(Exception):
+    """Raised when GitHub refuses or fails to create a gist."""
+
+
+def get_gist_token(guild_id: Optional[int]) -> Optional[str]:
+    """
+    Find the GitHub token for a server: GIST_TOKEN_<guild_id> if set, else GIST_TOKEN.
+
+    Tokens are only read from the environment so they never land in the bot's JSON storage.
+    """
+    if guild_id:
+        token = os.getenv(f'GIST_TOKEN_{guild_id}', '').strip()
+        if token:
+            return token
+    return os.getenv('GIST_TOKEN', '').strip() or None
+
+
+async def create_gist(token: str, filename: str, content: str, description: str) -> str:
+    """
+    Create a secret gist holding a single file.
+
+    Returns:
+        str: The gist's URL
+
+    Raises:
+        GistError: If the request fails or GitHub rejects it
+    """
+    payload = {
+        'description': description,
+        'public': False,
+        'files': {filename: {'content': content}},
+    }
+    headers = {
+        'Authorization': f'Bearer {token}',
+        'Accept': 'application/vnd.github+json',
+        'X-GitHub-Api-Version': '2022-11-28',
+    }
+    try:
+        timeout = aiohttp.ClientTimeout(total=GIST_TIMEOUT_SECONDS)
+        async with aiohttp.ClientSession(timeout=timeout) as session:
+            async with session.post(GIST_API_URL, json=payload, headers=headers) as response:
+                if response.status != 201:
+                    body = await response.text()
+                    raise GistError(f"GitHub returned {response.status}: {body[:200]}")
+                data = await response.json()
+    except (aiohttp.ClientError, TimeoutError) as e:
+        raise GistError(f"Could not reach GitHub: {e!r}") from e
+    return data['html_url']
@@ -0,0 +1,108 @@
+"""
+Minimal iCalendar (.ics) parsing for importing meetings.
+"""
+from dataclasses import dataclass
+from datetime import datetime, timezone
+from typing import Dict, List, Optional, Tuple
+from zoneinfo import ZoneInfo, ZoneInfoNotFoundError
+
+
+@dataclass
+class IcsEvent:
+    """The parts of a VEVENT that map onto a meeting."""
+    summary: str
+    start: datetime
+    url: str = ""
+    location: str = ""
+
+
+def _unfold(text: str) -> List[str]:
+    """Join folded lines (continuations start with a space or tab)."""
+    lines: List[str] = []
+    for line in text.replace('\r\n', '\n').split('\n'):
+        if line[:1] in (' ', '\t') and lines:
+            lines[-1] += line[1:]
+        elif line:
+            lines.append(line)
+    return lines
+
+
+def _unescape(value: str) -> str:
+    """Undo iCalendar TEXT escaping."""
+    return (value.replace('\\n', '\n').replace('\\N', '\n')
+            .replace('\\,', ',').replace('\\;', ';').replace('\\\\', '\\'))
+
+
+def _split_property(line: str) -> Tuple[str, Dict[str, str], str]:
+    """Split `NAME;PARAM=X:value` into its name, parameters, and value."""
+    head, _, value = line.partition(':')
+    name, *raw_params = head.split(';')
+    params = {}
+    for raw in raw_params:
+        key, _, param_value = raw.partition('=')
+        params[key.upper()] = param_value.strip('"')
+    return name.upper(), params, value
+
+
+def parse_ics_datetime(value: str, params: Dict[str, str]) -> datetime:
+    """
+    Parse a DTSTART value into an aware datetime.
+
+    UTC (`Z`) and TZID times are converted exactly; all-day dates start at
+    midnight and floating times are taken as the bot's local time.
+
+    Raises:
+        ValueError: If the value is not a valid date or date-time
+    """
+    if params.get('VALUE') == 'DATE' or len(value) == 8:
+        return datetime.strptime(value, '%Y%m%d').astimezone()
+    if value.endswith('Z'):
+        return datetime.strptime(value, '%Y%m%dT%H%M%SZ').replace(tzinfo=timezone.utc)
+
+    parsed = datetime.strptime(value, '%Y%m%dT%H%M%S')
+    tzid = params.get('TZID')
+    if tzid:
+        try:
+            return parsed.replace(tzinfo=ZoneInfo(tzid))
+        except (ZoneInfoNotFoundError, ValueError):
+            pass
+    return parsed.astimezone()
+
+
+def parse_ics(text: str) -> Tuple[List[IcsEvent], List[str]]:
+    """
+    Parse VEVENTs from calendar text.
+
+    Returns:
+        (events, problems): well-formed events, and a description of each malformed one
+    """
+    events: List[IcsEvent] = []
+    problems: List[str] = []
+    current: Optional[Dict[str, Tuple[Dict[str, str], str]]] = None
+
+    for line in _unfold(text):
+        name, params, value = _split_property(line)
+        if name == 'BEGIN' and value.upper() == 'VEVENT':
+            current = {}
+        elif name == 'END' and value.upper() == 'VEVENT' and current is not None:
+            label = _unescape(current.get('SUMMARY', ({}, ''))[1]).strip() or f"event #{len(events) + len(problems) + 1}"
+            if 'DTSTART' not in current:
+                problems.append(f"{label}: missing DTSTART")
+            else:
+                dt_params, dt_value = current['DTSTART']
+                try:
+                    start = parse_ics_datetime(dt_value.strip(), dt_params)
+                except ValueError:
+                    problems.append(f"{label}: invalid DTSTART {dt_value!r}")
+                else:
+                    events.append(IcsEvent(
+                        summary=_unescape(current.get('SUMMARY', ({}, ''))[1]).strip(),
+                        start=start,
+                        url=current.get('URL', ({}, ''))[1].strip(),
+                        location=_unescape(current.get('LOCATION', ({}, ''))[1]).strip()
+                    ))
+            current = None
+        elif current is not None:
+            current.setdefault(name, (params, value))
+
+    return events, problems
@@ -2,9 +2,25 @@
 Data models for the meeting bot.
 """
 import uuid
-from datetime import datetime
-from typing import List, Optional
-from dataclasses import dataclass, asdict
+from datetime import date, datetime, timedelta, timezone
+from typing import Dict, Iterable, List, Optional, Tuple
+from dataclasses import dataclass, asdict, field, fields
+
+from .config import get_max_length
+
+PUBLIC = 'public'
+PRIVATE = 'private'
+
+# Approval states for meetings created while a server requires approval
+PENDING = 'pending'
+APPROVED = 'approved'
+REJECTED = 'rejected'
+
+# Shown (and audited) instead of the author on meetings with anonymous updates
+ANONYMOUS_AUTHOR = 'Anonymous'
+
+# Standup questions every update answers, in form order
+UPDATE_FIELDS = ('progress', 'blockers', 'goals')
 
 
 @dataclass
@@ -21,22 +37,240 @@ class Update:
         self._validate()
     
     def _validate(self):
-        """Validate update fields."""
-        max_length = 500
-        
-        if not self.progress.strip():
-            raise ValueError("Progress field is required")
-        if not self.blockers.strip():
-            raise ValueError("Blockers field is required")
-        if not self.goals.strip():
-            raise ValueError("Goals field is required")
+        """Validate update fields; which ones may be blank depends on the server's settings."""
+        if not any(getattr(self, name).strip() for name in UPDATE_FIELDS):
+            raise ValueError("An update needs at least one answer")
+    
+    def validate_required(self, required: Iterable[str]):
+        """Check that the fields the server requires were filled in."""
+        for name in required:
+            if not getattr(self, name).strip():
+                raise ValueError(f"{name.capitalize()} field is required")
+    
+    def validate_lengths(self):
+        """Validate field lengths against the configured limits.
         
-        if len(self.progress) > max_length:
-            raise ValueError(f"Progress field must be {max_length} characters or less")
-        if len(self.blockers) > max_length:
-            raise ValueError(f"Blockers field must be {max_length} characters or less")
-        if len(self.goals) > max_length:
-            raise ValueError(f"Goals field must be {max_length} characters or less")
+        Only checked for new submissions so lowering a limit never breaks loading stored updates.
+        """
+        for label, field_key, value in (
+            ("Progress", 'UPDATE_PROGRESS', self.progress),
+            ("Blockers", 'UPDATE_BLOCKERS', self.blockers),
+            ("Goals", 'UPDATE_GOALS', self.goals),
+        ):
+            max_length = get_max_length(field_key)
+            if len(value) > max_length:
+                raise ValueError(f"{label} field must be {max_length} characters or less")
+
+
+def meeting_jump_url(guild_id: Optional[int], channel_id: int, message_id: int) -> str:
+    """Build a Discord URL that jumps to a message (DM channels use @me)."""
+    return f"https://discord.com/channels/{guild_id or '@me'}/{channel_id}/{message_id}"
+
+
+def parse_tags(raw: str) -> List[str]:
+    """Parse comma-separated tags into lowercase, de-duplicated values (order preserved)."""
+    tags = []
+    for tag in raw.split(','):
+        tag = tag.strip().lower()
+        if tag and tag not in tags:
+            tags.append(tag)
+    return tags
+
+
+@dataclass
+class Decision:
+    """Represents a decision recorded during a meeting."""
+    meeting_id: str
+    text: str
+    decided_by: str
+    decided_at: str
+
+
+@dataclass
+class Rating:
+    """Represents an attendee's 1-5 rating of a closed meeting."""
+    user: str
+    score: int
+    comment: str
+    rated_at: str
+
+
+def _field_text(value) -> str:
+    """Render a field value as text for change history (tags are lists)."""
+    if isinstance(value, list):
+        return ", ".join(value)
+    return value or ""
+
+
+@dataclass
+class FieldChange:
+    """A single field's value before and after an edit."""
+    field: str
+    old: str
+    new: str
+
+
+@dataclass
+class MeetingChange:
+    """One edit to a meeting and the fields it changed."""
+    meeting_id: str
+    changed_by: str
+    changed_at: str
+    changes: List[FieldChange]
+    reverted: bool = False
+
+    @classmethod
+    def from_dict(cls, data: dict) -> 'MeetingChange':
+        """Create a change record from dictionary."""
+        return cls(
+            meeting_id=data['meeting_id'],
+            changed_by=data['changed_by'],
+            changed_at=data['changed_at'],
+            changes=[FieldChange(**change) for change in data.get('changes', [])],
+            reverted=data.get('reverted', False)
+        )
+
+
+@dataclass
+class AuditEvent:
+    """Represents a single entry in a meeting's audit trail."""
+    event_type: str
+    actor: str
+    meeting_id: str
+    timestamp: str
+
+    @classmethod
+    def create_new(cls, event_type: str, actor: str, meeting_id: str) -> 'AuditEvent':
+        """Create a new audit event stamped with the current time."""
+        return cls(
+            event_type=event_type,
+            actor=actor,
+            meeting_id=meeting_id,
+            timestamp=datetime.now().isoformat()
+        )
+
+
+def toggle_optional_field(optional_fields: List[str], name: str) -> List[str]:
+    """Flip an update question between required and optional, keeping at least one required."""
+    if name in optional_fields:
+        return [field_name for field_name in optional_fields if field_name != name]
+    if len(optional_fields) + 1 >= len(UPDATE_FIELDS):
+        raise ValueError("At least one update question must stay required")
+    return [*optional_fields, name]
+
+
+@dataclass
+class GuildSettings:
+    """Per-server settings changed at runtime by managers."""
+    guild_id: int
+    optional_update_fields: List[str] = field(default_factory=list)
+    disabled: bool = False
+    # New public meetings wait for a member of this role to approve them before being announced
+    approver_role_id: Optional[int] = None
+    # Set when the bot is removed from the server, so scheduled posts skip it
+    inactive: bool = False
+
+    @property
+    def required_update_fields(self) -> List[str]:
+        """Update fields members must fill in, in form order."""
+        return [name for name in UPDATE_FIELDS if name not in self.optional_update_fields]
+
+    def to_dict(self):
+        """Convert settings to dictionary for JSON serialization."""
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: dict) -> 'GuildSettings':
+        """Create settings from dictionary, ignoring unknown keys."""
+        known = {f.name for f in fields(cls)}
+        return cls(**{key: value for key, value in data.items() if key in known})
+
+
+@dataclass
+class ChannelSettings:
+    """Per-channel overrides of a server's settings; None means the server's value applies."""
+    channel_id: int
+    optional_update_fields: Optional[List[str]] = None
+
+    def to_dict(self):
+        """Convert settings to dictionary for JSON serialization."""
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: dict) -> 'ChannelSettings':
+        """Create settings from dictionary, ignoring unknown keys."""
+        known = {f.name for f in fields(cls)}
+        return cls(**{key: value for key, value in data.items() if key in known})
+
+
+@dataclass
+class UserPreferences:
+    """Per-user settings and one-time flags."""
+    user_id: int
+    seen_update_tips: bool = False
+    dm_close_summary: bool = False
+    compact_list: bool = True
+    # Notification DMs are skipped until this time, e.g. while the user is on leave
+    dnd_until: Optional[str] = None
+
+    def dnd_remaining(self, now: Optional[datetime] = None) -> Optional[timedelta]:
+        """Time left on the user's do-not-disturb, or None if it's off or has expired."""
+        if not self.dnd_until:
+            return None
+        remaining = datetime.fromisoformat(self.dnd_until) - (now or datetime.now())
+        return remaining if remaining > timedelta(0) else None
+
+    def to_dict(self):
+        """Convert preferences to dictionary for JSON serialization."""
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: dict) -> 'UserPreferences':
+        """Create preferences from dictionary, ignoring unknown keys."""
+        known = {f.name for f in fields(cls)}
+        return cls(**{key: value for key, value in data.items() if key in known})
+
+
+@dataclass
+class Announcement:
+    """A plain message scheduled to be posted to a channel once, independent of any meeting."""
+    id: str
+    guild_id: int
+    channel_id: int
+    created_by: str
+    message: str
+    # Timezone-aware, so it compares correctly however the bot's clock is set
+    post_at: str
+    created_at: str
+
+    def is_due(self, now: datetime) -> bool:
+        """Whether the announcement's time has come."""
+        return datetime.fromisoformat(self.post_at) <= now
+
+    def to_dict(self):
+        """Convert announcement to dictionary for JSON serialization."""
+        return asdict(self)
+
+    @classmethod
+    def from_dict(cls, data: dict) -> 'Announcement':
+        """Create announcement from dictionary, ignoring unknown keys."""
+        known = {f.name for f in fields(cls)}
+        return cls(**{key: value for key, value in data.items() if key in known})
+
+    @classmethod
+    def create_new(cls, guild_id: int, channel_id: int, created_by: str, message: str, post_at: datetime) -> 'Announcement':
+        """Create a new announcement with a short random ID."""
+        if post_at.tzinfo is None:
+            raise ValueError("Announcement times must include a timezone")
+        return cls(
+            id=uuid.uuid4().hex[:8],
+            guild_id=guild_id,
+            channel_id=channel_id,
+            created_by=created_by,
+            message=message,
+            post_at=post_at.isoformat(),
+            created_at=datetime.now(timezone.utc).isoformat()
+        )
 
 
 @dataclass
@@ -50,22 +284,223 @@ class Meeting:
     link: str
     is_closed: bool = False
     closed_at: Optional[str] = None
+    is_archived: bool = False
+    archived_at: Optional[str] = None
+    location: str = ""
+    tags: List[str] = field(default_factory=list)
+    visibility: str = PUBLIC
+    anonymous_updates: bool = False
+    co_hosts: List[str] = field(default_factory=list)
+    roster: List[str] = field(default_factory=list)
+    # Discord IDs behind the names above, so members can be found without relying on the member cache
+    user_ids: Dict[str, int] = field(default_factory=dict)
+    decisions: List[Decision] = field(default_factory=list)
+    ratings: List[Rating] = field(default_factory=list)
+    guild_id: Optional[int] = None
+    channel_id: Optional[int] = None
+    message_id: Optional[int] = None
+    thread_id: Optional[int] = None
+    version: int = 0
+    # None unless the meeting was created while its server required approval
+    approval: Optional[str] = None
+    reviewed_by: Optional[str] = None
+    reviewed_at: Optional[str] = None
+
+    @property
+    def is_pending(self) -> bool:
+        """Whether the meeting is still waiting for an approver."""
+        return self.approval == PENDING
 
-    def add_update(self, user: str, progress: str, blockers: str, goals: str) -> Update:
-        """Add a new update to the meeting."""
+    @property
+    def jump_url(self) -> Optional[str]:
+        """URL of the meeting's card message, if it has been posted."""
+        if not (self.channel_id and self.message_id):
+            return None
+        return meeting_jump_url(self.guild_id, self.channel_id, self.message_id)
+
+    def update_author(self, update: Update) -> str:
+        """Name to show for an update; authors are still stored so resubmissions can be matched."""
+        return ANONYMOUS_AUTHOR if self.anonymous_updates else update.user
+    
+    def roster_status(self) -> Tuple[List[str], List[str]]:
+        """Split the expected participants into those who have submitted an update and those who haven't."""
+        submitted_users = {update.user for update in self.updates}
+        submitted = [user for user in self.roster if user in submitted_users]
+        missing = [user for user in self.roster if user not in submitted_users]
+        return submitted, missing
+    
+    def remember_user(self, user: str, user_id: int):
+        """Record the Discord ID behind a stored user name, replacing any older one."""
+        self.user_ids[user] = user_id
+    
+    def is_host(self, user: str) -> bool:
+        """Check whether a user created or co-hosts the meeting."""
+        return user == self.created_by or user in self.co_hosts
+    
+    def is_attendee(self, user: str) -> bool:
+        """Check whether a user hosted, was expected at, or posted an update to the meeting."""
+        return self.is_host(user) or user in self.roster or any(update.user == user for update in self.updates)
+    
+    @property
+    def average_rating(self) -> Optional[float]:
+        """Mean of the attendees' ratings, or None if nobody has rated the meeting."""
+        if not self.ratings:
+            return None
+        return sum(rating.score for rating in self.ratings) / len(self.ratings)
+    
+    def find_rating(self, user: str) -> Optional[Rating]:
+        """Find a user's rating of the meeting, if they've left one."""
+        for rating in self.ratings:
+            if rating.user == user:
+                return rating
+        return None
+    
+    def is_visible_to(self, user: str, is_manager: bool = False) -> bool:
+        """Private meetings are only visible to their creator, participants, and managers."""
+        if self.visibility != PRIVATE or is_manager or self.is_host(user):
+            return True
+        return any(update.user == user for update in self.updates)
+    
+    def find_update(self, user: str, day: date) -> Optional[Update]:
+        """Find the update a user submitted on a given day, if any."""
+        for update in self.updates:
+            if update.user == user and datetime.fromisoformat(update.timestamp).date() == day:
+                return update
+        return None
+    
+    def add_update(self, user: str, progress: str, blockers: str, goals: str, required: Iterable[str] = UPDATE_FIELDS) -> Tuple[Update, bool]:
+        """
+        Add an update to the meeting, keeping at most one per user per day.
+        
+        Returns:
+            (update, replaced): The stored update, and whether it overwrote the user's earlier update from today
+        """
         if self.is_closed:
             raise ValueError("Cannot add updates to a closed meeting")
+        if self.is_archived:
+            raise ValueError("Cannot add updates to an archived meeting")
+        if self.is_pending:
+            raise ValueError("Cannot add updates to a meeting awaiting approval")
         
+        now = datetime.now()
         update = Update(
             user=user,
             progress=progress,
             blockers=blockers,
             goals=goals,
-            timestamp=datetime.now().isoformat()
+            timestamp=now.isoformat()
         )
+        update.validate_required(required)
+        update.validate_lengths()
+        
+        existing = self.find_update(user, now.date())
+        if existing:
+            self.updates[self.updates.index(existing)] = update
+            return update, True
         
         self.updates.append(update)
-        return update
+        return update, False
+    
+    def revert_change(self, change: MeetingChange) -> List[FieldChange]:
+        """Put the fields an edit changed back to their earlier values, returning what changed."""
+        values = {'name': self.name, 'link': self.link, 'location': self.location, 'tags': self.tags}
+        for field_change in change.changes:
+            if field_change.field == 'tags':
+                values['tags'] = parse_tags(field_change.old)
+            elif field_change.field in values:
+                values[field_change.field] = field_change.old
+        return self.apply_edits(**values)
+    
+    def merge_from(self, source: 'Meeting') -> int:
+        """
+        Move a duplicate meeting's updates, decisions, co-hosts, and roster into this one.
+        
+        Where both meetings have an update from the same user on the same day, this
+        meeting's update is kept, preserving the one-update-per-day rule.
+        
+        Returns:
+            int: How many of the source's updates were skipped as same-day duplicates
+        """
+        if source.id == self.id:
+            raise ValueError("Cannot merge a meeting into itself")
+        if source.guild_id != self.guild_id:
+            raise ValueError("Cannot merge meetings from different servers")
+        if self.is_closed or self.is_archived:
+            raise ValueError("Meetings can only be merged into an open meeting")
+        
+        skipped = 0
+        for update in source.updates:
+            if self.find_update(update.user, datetime.fromisoformat(update.timestamp).date()):
+                skipped += 1
+            else:
+                self.updates.append(update)
+        self.updates.sort(key=lambda update: update.timestamp)
+        
+        for decision in source.decisions:
+            decision.meeting_id = self.id
+            self.decisions.append(decision)
+        self.decisions.sort(key=lambda decision: decision.decided_at)
+        
+        for user in [source.created_by, *source.co_hosts]:
+            if not self.is_host(user):
+                self.co_hosts.append(user)
+        self.roster.extend(user for user in source.roster if user not in self.roster)
+        for user, user_id in source.user_ids.items():
+            self.user_ids.setdefault(user, user_id)
+        return skipped
+    
+    def apply_edits(self, name: str, link: str, location: str, tags: List[str]) -> List[FieldChange]:
+        """Update the editable fields, returning what actually changed."""
+        if self.is_closed or self.is_archived:
+            raise ValueError("Cannot edit a meeting that is no longer open")
+        
+        new_values = {'name': name or self.id, 'link': link, 'location': location, 'tags': tags}
+        changes = []
+        for field_name, new in new_values.items():
+            old = getattr(self, field_name)
+            if old != new:
+                changes.append(FieldChange(field=field_name, old=_field_text(old), new=_field_text(new)))
+                setattr(self, field_name, new)
+        return changes
+    
+    def add_decision(self, text: str, decided_by: str) -> Decision:
+        """Record a decision made in the meeting."""
+        if self.is_closed or self.is_archived:
+            raise ValueError("Cannot add decisions to a meeting that is no longer open")
+        if not text.strip():
+            raise ValueError("Decision text is required")
+        
+        decision = Decision(
+            meeting_id=self.id,
+            text=text.strip(),
+            decided_by=decided_by,
+            decided_at=datetime.now().isoformat()
+        )
+        self.decisions.append(decision)
+        return decision
+    
+    def rate(self, user: str, score: int, comment: str = "") -> Tuple[Rating, bool]:
+        """
+        Record a user's rating of the closed meeting, replacing their earlier one.
+
+        Returns:
+            Tuple[Rating, bool]: The rating and whether it replaced an earlier one
+        """
+        if not self.is_closed:
+            raise ValueError("Meetings can only be rated once they're closed")
+        if not 1 <= score <= 5:
+            raise ValueError("Ratings must be from 1 to 5")
+        
+        existing = self.find_rating(user)
+        if existing:
+            existing.score = score
+            existing.comment = comment.strip()
+            existing.rated_at = datetime.now().isoformat()
+            return existing, True
+        
+        rating = Rating(user=user, score=score, comment=comment.strip(), rated_at=datetime.now().isoformat())
+        self.ratings.append(rating)
+        return rating, False
     
     def close(self):
         """Close the meeting."""
@@ -75,6 +510,36 @@ class Meeting:
         self.is_closed = True
         self.closed_at = datetime.now().isoformat()
     
+    def review(self, reviewer: str, approved: bool):
+        """Record an approver's decision on a pending meeting; rejected meetings are closed."""
+        if not self.is_pending:
+            raise ValueError("Meeting is not awaiting approval")
+        
+        self.approval = APPROVED if approved else REJECTED
+        self.reviewed_by = reviewer
+        self.reviewed_at = datetime.now().isoformat()
+        if not approved:
+            self.close()
+    
+    def clear_updates(self) -> int:
+        """
+        Remove every submitted update so participants can start over.
+
+        Returns:
+            int: How many updates were removed
+        """
+        count = len(self.updates)
+        self.updates = []
+        return count
+    
+    def archive(self):
+        """Archive the meeting, hiding it from the default list without a summary."""
+        if self.is_archived:
+            raise ValueError("Meeting is already archived")
+        
+        self.is_archived = True
+        self.archived_at = datetime.now().isoformat()
+    
     def to_dict(self):
         """Convert meeting to dictionary for JSON serialization."""
         return {
@@ -84,8 +549,27 @@ class Meeting:
             'updates': [asdict(update) for update in self.updates],
             'is_closed': self.is_closed,
             'closed_at': self.closed_at,
+            'is_archived': self.is_archived,
+            'archived_at': self.archived_at,
             'name': self.name,
-            'link': self.link
+            'link': self.link,
+            'location': self.location,
+            'tags': self.tags,
+            'visibility': self.visibility,
+            'anonymous_updates': self.anonymous_updates,
+            'co_hosts': self.co_hosts,
+            'roster': self.roster,
+            'user_ids': self.user_ids,
+            'decisions': [asdict(decision) for decision in self.decisions],
+            'ratings': [asdict(rating) for rating in self.ratings],
+            'guild_id': self.guild_id,
+            'channel_id': self.channel_id,
+            'message_id': self.message_id,
+            'thread_id': self.thread_id,
+            'version': self.version,
+            'approval': self.approval,
+            'reviewed_by': self.reviewed_by,
+            'reviewed_at': self.reviewed_at
         }
     
     @classmethod
@@ -100,12 +584,31 @@ class Meeting:
             updates=updates,
             is_closed=data.get('is_closed', False),
             closed_at=data.get('closed_at'),
+            is_archived=data.get('is_archived', False),
+            archived_at=data.get('archived_at'),
             name=data.get('name'),
-            link=data.get('link')
+            link=data.get('link'),
+            location=data.get('location', ''),
+            tags=data.get('tags', []),
+            visibility=data.get('visibility', PUBLIC),
+            anonymous_updates=data.get('anonymous_updates', False),
+            co_hosts=data.get('co_hosts', []),
+            roster=data.get('roster', []),
+            user_ids=data.get('user_ids', {}),
+            decisions=[Decision(**decision_data) for decision_data in data.get('decisions', [])],
+            ratings=[Rating(**rating_data) for rating_data in data.get('ratings', [])],
+            guild_id=data.get('guild_id'),
+            channel_id=data.get('channel_id'),
+            message_id=data.get('message_id'),
+            thread_id=data.get('thread_id'),
+            version=data.get('version', 0),
+            approval=data.get('approval'),
+            reviewed_by=data.get('reviewed_by'),
+            reviewed_at=data.get('reviewed_at')
         )
     
     @classmethod
-    def create_new(cls, created_by: str, name: str, link: str) -> 'Meeting':
+    def create_new(cls, created_by: str, name: str, link: str, location: str = "", tags: Optional[List[str]] = None, visibility: str = PUBLIC, anonymous_updates: bool = False) -> 'Meeting':
         """Create a new meeting."""
         now = datetime.now()
         # Prefix ID with yy-m-d (e.g., 25-9-10) and append short random suffix for uniqueness
@@ -118,6 +621,10 @@ class Meeting:
             created_at=now.isoformat(),
             updates=[],
             name=name if name else meeting_id,
-            link=link
+            link=link,
+            location=location,
+            tags=tags or [],
+            visibility=visibility,
+            anonymous_updates=anonymous_updates
         )
 
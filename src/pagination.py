@@ -0,0 +1,76 @@
+"""
+Paginated embed view for long lists of lines.
+"""
+from typing import List
+
+import discord
+
+from .views import ExpiringView
+
+# Discord rejects embeds whose description is longer than this
+EMBED_DESCRIPTION_LIMIT = 4096
+
+
+def split_pages(lines: List[str], per_page: int, max_chars: int = EMBED_DESCRIPTION_LIMIT) -> List[List[str]]:
+    """
+    Group lines into pages of at most `per_page` lines whose joined text fits in `max_chars`.
+
+    A single line longer than `max_chars` is cut short so it still fits on a page of its own.
+    """
+    pages = []
+    page, size = [], 0
+    for line in lines:
+        if len(line) > max_chars:
+            line = line[:max_chars - 1] + "…"
+        added = len(line) + (1 if page else 0)
+        if page and (len(page) >= per_page or size + added > max_chars):
+            pages.append(page)
+            page, size = [], 0
+            added = len(line)
+        page.append(line)
+        size += added
+    if page:
+        pages.append(page)
+    return pages or [[]]
+
+
+class PaginatedEmbedView(ExpiringView):
+    """Splits lines across embed pages with previous/next buttons."""
+
+    def __init__(self, title: str, lines: List[str], color: int = 0x3b82f6, per_page: int = 10):
+        super().__init__(timeout=300)
+        self.title = title
+        self.color = color
+        self.pages = split_pages(lines, per_page)
+        self.page = 0
+        self._refresh_buttons()
+
+    def build_embed(self) -> discord.Embed:
+        """Build the embed for the current page."""
+        lines = self.pages[self.page]
+        embed = discord.Embed(
+            title=self.title,
+            description="\n".join(lines) if lines else "Nothing to show.",
+            color=self.color
+        )
+        embed.set_footer(text=f"Page {self.page + 1}/{len(self.pages)}")
+        return embed
+
+    def _refresh_buttons(self):
+        """Enable or disable the navigation buttons for the current page."""
+        self.previous_page.disabled = self.page == 0
+        self.next_page.disabled = self.page >= len(self.pages) - 1
+
+    @discord.ui.button(label="Previous", style=discord.ButtonStyle.secondary)
+    async def previous_page(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Go to the previous page."""
+        self.page -= 1
+        self._refresh_buttons()
+        await interaction.response.edit_message(embed=self.build_embed(), view=self)
+
+    @discord.ui.button(label="Next", style=discord.ButtonStyle.secondary)
+    async def next_page(self, interaction: discord.Interaction, button: discord.ui.Button):
+        """Go to the next page."""
+        self.page += 1
+        self._refresh_buttons()
+        await interaction.response.edit_message(embed=self.build_embed(), view=self)
@@ -2,10 +2,22 @@
 HTML report generation for meetings using Jinja2 templates.
 """
 import os
+import csv
+import io
 from pathlib import Path
 from jinja2 import Environment, FileSystemLoader
 from typing import Optional
 from .models import Meeting
+from .analytics import compute_attendance
+from .tracing import log
+
+# Spreadsheets run cells starting with these as formulas
+CSV_FORMULA_PREFIXES = ('=', '+', '-', '@', '\t', '\r')
+
+
+def escape_csv_cell(value: str) -> str:
+    """Quote user text that a spreadsheet would otherwise evaluate as a formula."""
+    return f"'{value}" if value.startswith(CSV_FORMULA_PREFIXES) else value
 
 
 class ReportGenerator:
@@ -36,13 +48,90 @@ class ReportGenerator:
             template = self.jinja_env.get_template('meeting_report.html')
             html_content = template.render(meeting=meeting)
             
-            print(f"Successfully generated HTML report for meeting {meeting.id}")
+            log(f"Successfully generated HTML report for meeting {meeting.id}")
             return html_content
             
         except Exception as e:
-            print(f"Error generating HTML report for meeting {meeting.id}: {e}")
+            log(f"Error generating HTML report for meeting {meeting.id}: {e}")
             return None
     
+    def generate_attendance_csv(self, meeting: Meeting) -> str:
+        """
+        Generate a CSV attendance sheet for a meeting, one row per rostered member or poster.
+        
+        Meetings with anonymous updates get a roster-only sheet without the attendance columns.
+        
+        Args:
+            meeting: The Meeting object to take attendance for
+            
+        Returns:
+            str: CSV text with a header row
+        """
+        buffer = io.StringIO()
+        writer = csv.writer(buffer)
+        if meeting.anonymous_updates:
+            writer.writerow(["meeting_id", "meeting_name", "member", "on_roster"])
+            for record in compute_attendance(meeting):
+                writer.writerow([meeting.id, escape_csv_cell(meeting.name), escape_csv_cell(record.user), "yes"])
+            return buffer.getvalue()
+        
+        writer.writerow(["meeting_id", "meeting_name", "member", "on_roster", "attended", "updates", "first_update_at"])
+        for record in compute_attendance(meeting):
+            writer.writerow([
+                meeting.id,
+                escape_csv_cell(meeting.name),
+                escape_csv_cell(record.user),
+                "yes" if record.on_roster else "no",
+                "yes" if record.updates else "no",
+                record.updates,
+                record.first_update_at or ""
+            ])
+        return buffer.getvalue()
+    
+    def generate_markdown_minutes(self, meeting: Meeting) -> str:
+        """
+        Generate Markdown minutes for a meeting, e.g. for DMing to its creator.
+        
+        Args:
+            meeting: The Meeting object to summarize
+            
+        Returns:
+            str: Markdown content
+        """
+        lines = [f"# {meeting.name}", "", f"- **Meeting ID:** {meeting.id}", f"- **Created by:** {meeting.created_by}"]
+        if meeting.co_hosts:
+            lines.append(f"- **Co-hosts:** {', '.join(meeting.co_hosts)}")
+        lines.append(f"- **Created at:** {meeting.created_at}")
+        if meeting.closed_at:
+            lines.append(f"- **Closed at:** {meeting.closed_at}")
+        if meeting.link:
+            lines.append(f"- **Link:** {meeting.link}")
+        if meeting.location:
+            lines.append(f"- **Location:** {meeting.location}")
+        if meeting.tags:
+            lines.append(f"- **Tags:** {', '.join(meeting.tags)}")
+        
+        if meeting.decisions:
+            lines += ["", "## Decisions", ""]
+            lines += [f"- {decision.text} ({decision.decided_by})" for decision in meeting.decisions]
+        
+        lines += ["", f"## Updates ({len(meeting.updates)})"]
+        if not meeting.updates:
+            lines += ["", "No updates were submitted."]
+        for update in meeting.updates:
+            lines += [
+                "",
+                f"### {meeting.update_author(update)}",
+                "",
+                f"**Progress:** {update.progress or '—'}",
+                "",
+                f"**Blockers:** {update.blockers or '—'}",
+                "",
+                f"**Goals:** {update.goals or '—'}",
+            ]
+        
+        return "\n".join(lines) + "\n"
+    
     def save_html_report(self, meeting: Meeting, output_dir: str = "reports") -> Optional[str]:
         """
         Generate and save HTML report to local file.
@@ -69,11 +158,11 @@ class ReportGenerator:
             with open(report_file, 'w', encoding='utf-8') as f:
                 f.write(html_content)
             
-            print(f"HTML report saved to: {report_file}")
+            log(f"HTML report saved to: {report_file}")
             return str(report_file)
             
         except Exception as e:
-            print(f"Error saving HTML report for meeting {meeting.id}: {e}")
+            log(f"Error saving HTML report for meeting {meeting.id}: {e}")
             return None
     
     def get_template_path(self) -> Path:
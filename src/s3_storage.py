@@ -7,6 +7,8 @@ import boto3
 from botocore.exceptions import ClientError, NoCredentialsError
 from typing import Optional
 
+from .tracing import log
+
 
 class S3Storage:
     """Handles S3 operations for meeting data and reports."""
@@ -52,7 +54,7 @@ class S3Storage:
             bool: True if successful, False otherwise
         """
         if not self.is_available():
-            print(f"S3 not available, skipping upload for meeting {meeting_id}")
+            log(f"S3 not available, skipping upload for meeting {meeting_id}")
             return False
         
         try:
@@ -68,14 +70,14 @@ class S3Storage:
                 ContentType='application/json'
             )
             
-            print(f"Successfully uploaded meeting JSON for {meeting_id} to S3")
+            log(f"Successfully uploaded meeting JSON for {meeting_id} to S3")
             return True
             
         except ClientError as e:
-            print(f"Error uploading meeting JSON for {meeting_id}: {e}")
+            log(f"Error uploading meeting JSON for {meeting_id}: {e}")
             return False
         except Exception as e:
-            print(f"Unexpected error uploading meeting JSON for {meeting_id}: {e}")
+            log(f"Unexpected error uploading meeting JSON for {meeting_id}: {e}")
             return False
     
     def upload_html_report(self, meeting_id: str, html_content: str) -> bool:
@@ -90,7 +92,7 @@ class S3Storage:
             bool: True if successful, False otherwise
         """
         if not self.is_available():
-            print(f"S3 not available, skipping HTML upload for meeting {meeting_id}")
+            log(f"S3 not available, skipping HTML upload for meeting {meeting_id}")
             return False
         
         try:
@@ -103,27 +105,28 @@ class S3Storage:
                 ContentType='text/html'
             )
             
-            print(f"Successfully uploaded HTML report for {meeting_id} to S3")
+            log(f"Successfully uploaded HTML report for {meeting_id} to S3")
             return True
             
         except ClientError as e:
-            print(f"Error uploading HTML report for {meeting_id}: {e}")
+            log(f"Error uploading HTML report for {meeting_id}: {e}")
             return False
         except Exception as e:
-            print(f"Unexpected error uploading HTML report for {meeting_id}: {e}")
+            log(f"Unexpected error uploading HTML report for {meeting_id}: {e}")
             return False
     
-    def generate_presigned_url(self, meeting_id: str) -> str:
+    def generate_presigned_url(self, meeting_id: str, expires_in: int = 3600) -> str:
         """
         Generate a presigned URL for the HTML report.
         
         Args:
             meeting_id: The meeting ID
+            expires_in: Seconds until the URL stops working
             
         Returns:
             str: The presigned URL
         """
-        return self.s3_client.generate_presigned_url('get_object', Params={'Bucket': self.bucket_name, 'Key': f'meetings/{meeting_id}/index.html'}, ExpiresIn=3600)
+        return self.s3_client.generate_presigned_url('get_object', Params={'Bucket': self.bucket_name, 'Key': f'meetings/{meeting_id}/index.html'}, ExpiresIn=expires_in)
     
     def test_connection(self) -> bool:
         """
@@ -137,12 +140,12 @@ class S3Storage:
         
         try:
             self.s3_client.head_bucket(Bucket=self.bucket_name)
-            print(f"S3 connection test successful for bucket: {self.bucket_name}")
+            log(f"S3 connection test successful for bucket: {self.bucket_name}")
             return True
         except ClientError as e:
-            print(f"S3 connection test failed: {e}")
+            log(f"S3 connection test failed: {e}")
             return False
         except Exception as e:
-            print(f"Unexpected error testing S3 connection: {e}")
+            log(f"Unexpected error testing S3 connection: {e}")
             return False
 
@@ -2,9 +2,53 @@
 Storage system for meetings using JSON files.
 """
 import json
+import threading
 from pathlib import Path
-from typing import Optional, List
-from .models import Meeting
+from datetime import datetime
+from dataclasses import asdict
+from typing import Dict, Optional, List, Iterator, Tuple
+from .models import Meeting, Announcement, AuditEvent, MeetingChange, UserPreferences, GuildSettings, ChannelSettings
+from .cache import TTLCache
+from .tracing import log
+
+# Cached listings may lag writes from other processes by at most this long
+CACHE_TTL_SECONDS = 30
+
+
+class StorageUnavailableError(Exception):
+    """Raised when meeting storage cannot be reached or does not respond in time."""
+
+
+class MeetingNotFoundError(LookupError):
+    """Raised when a meeting does not exist (or its stored data cannot be read)."""
+    
+    def __init__(self, meeting_id: str):
+        super().__init__(f"Meeting {meeting_id} not found")
+        self.meeting_id = meeting_id
+
+
+class MeetingClosedError(ValueError):
+    """Raised when an open meeting is required but it has been closed or archived."""
+    
+    def __init__(self, meeting_id: str):
+        super().__init__(f"Meeting {meeting_id} is no longer open")
+        self.meeting_id = meeting_id
+
+
+class DuplicateMeetingError(ValueError):
+    """Raised when creating a meeting whose ID is already taken."""
+    
+    def __init__(self, meeting_id: str):
+        super().__init__(f"Meeting {meeting_id} already exists")
+        self.meeting_id = meeting_id
+
+
+class ConcurrentModificationError(RuntimeError):
+    """Raised when a meeting was saved by someone else since the caller loaded it."""
+    
+    def __init__(self, meeting_id: str):
+        super().__init__(f"Meeting {meeting_id} was changed by someone else")
+        self.meeting_id = meeting_id
 
 
 class MeetingStorage:
@@ -13,6 +57,9 @@ class MeetingStorage:
     def __init__(self, storage_dir: str = "json"):
         self.storage_dir = Path(storage_dir)
         self.storage_dir.mkdir(exist_ok=True)
+        self.cache = TTLCache(CACHE_TTL_SECONDS)
+        # Storage calls run in worker threads; version checks and writes must not interleave
+        self._write_lock = threading.Lock()
     
     def _get_meeting_path(self, meeting_id: str) -> Path:
         """Get the file path for a meeting."""
@@ -20,12 +67,60 @@ class MeetingStorage:
         meeting_dir.mkdir(exist_ok=True)
         return meeting_dir / "meeting.json"
     
-    def save_meeting(self, meeting: Meeting) -> None:
-        """Save a meeting to storage."""
+    def _get_user_prefs_path(self, user_id: int) -> Path:
+        """Get the file path for a user's preferences."""
+        users_dir = self.storage_dir / "_users"
+        users_dir.mkdir(exist_ok=True)
+        return users_dir / f"{user_id}.json"
+    
+    def _get_guild_settings_path(self, guild_id: int) -> Path:
+        """Get the file path for a server's settings."""
+        guilds_dir = self.storage_dir / "_guilds"
+        guilds_dir.mkdir(exist_ok=True)
+        return guilds_dir / f"{guild_id}.json"
+    
+    def _get_channel_settings_path(self, channel_id: int) -> Path:
+        """Get the file path for a channel's setting overrides."""
+        channels_dir = self.storage_dir / "_channels"
+        channels_dir.mkdir(exist_ok=True)
+        return channels_dir / f"{channel_id}.json"
+    
+    def _get_announcement_path(self, announcement_id: str) -> Path:
+        """Get the file path for a scheduled announcement."""
+        announcements_dir = self.storage_dir / "_announcements"
+        announcements_dir.mkdir(exist_ok=True)
+        return announcements_dir / f"{announcement_id}.json"
+    
+    def _get_audit_path(self, meeting_id: str) -> Path:
+        """Get the file path for a meeting's audit log."""
+        meeting_dir = self.storage_dir / meeting_id
+        meeting_dir.mkdir(exist_ok=True)
+        return meeting_dir / "audit.jsonl"
+    
+    def save_meeting(self, meeting: Meeting, expected_version: Optional[int] = None) -> None:
+        """
+        Save a meeting to storage, bumping its version.
+        
+        Args:
+            meeting: The meeting to save
+            expected_version: If given, the version the caller's changes were based on;
+                the save is refused if the stored meeting has moved past it
+        
+        Raises:
+            ConcurrentModificationError: If the stored version doesn't match expected_version
+        """
         meeting_path = self._get_meeting_path(meeting.id)
         
-        with open(meeting_path, 'w', encoding='utf-8') as f:
-            json.dump(meeting.to_dict(), f, indent=2, ensure_ascii=False)
+        with self._write_lock:
+            stored = self.load_meeting(meeting.id)
+            stored_version = stored.version if stored else 0
+            if expected_version is not None and stored_version != expected_version:
+                raise ConcurrentModificationError(meeting.id)
+            
+            meeting.version = stored_version + 1
+            with open(meeting_path, 'w', encoding='utf-8') as f:
+                json.dump(meeting.to_dict(), f, indent=2, ensure_ascii=False)
+        self.cache.invalidate()
     
     def load_meeting(self, meeting_id: str) -> Optional[Meeting]:
         """Load a meeting from storage."""
@@ -39,9 +134,72 @@ class MeetingStorage:
                 data = json.load(f)
             return Meeting.from_dict(data)
         except (json.JSONDecodeError, KeyError, ValueError) as e:
-            print(f"Error loading meeting {meeting_id}: {e}")
+            log(f"Error loading meeting {meeting_id}: {e}")
             return None
     
+    def get_meeting(self, meeting_id: str) -> Meeting:
+        """Load a meeting, raising MeetingNotFoundError instead of returning None."""
+        meeting = self.load_meeting(meeting_id)
+        if meeting is None:
+            raise MeetingNotFoundError(meeting_id)
+        return meeting
+    
+    def load_meeting_record(self, meeting_id: str) -> dict:
+        """
+        Load a meeting's stored JSON as-is, without building a Meeting from it.
+        
+        Raises MeetingNotFoundError if there is no record, and JSONDecodeError if it is corrupt.
+        """
+        meeting_path = self._get_meeting_path(meeting_id)
+        if not meeting_path.exists():
+            raise MeetingNotFoundError(meeting_id)
+        with open(meeting_path, 'r', encoding='utf-8') as f:
+            return json.load(f)
+    
+    def count_related_records(self, meeting_id: str) -> Dict[str, int]:
+        """Count the lines in a meeting's audit and change logs, including ones that can't be parsed."""
+        counts = {}
+        for name, path in (("audit_events", self._get_audit_path(meeting_id)), ("changes", self._get_changes_path(meeting_id))):
+            if not path.exists():
+                counts[name] = 0
+                continue
+            with open(path, 'r', encoding='utf-8') as f:
+                counts[name] = sum(1 for line in f if line.strip())
+        return counts
+    
+    def get_open_meeting(self, meeting_id: str) -> Meeting:
+        """Load a meeting that accepts updates, raising MeetingClosedError if it is closed, archived, or awaiting approval."""
+        meeting = self.get_meeting(meeting_id)
+        if meeting.is_closed or meeting.is_archived or meeting.is_pending:
+            raise MeetingClosedError(meeting_id)
+        return meeting
+    
+    def create_meeting(self, meeting: Meeting) -> None:
+        """Save a new meeting, raising DuplicateMeetingError if the ID is taken."""
+        if self.meeting_exists(meeting.id):
+            raise DuplicateMeetingError(meeting.id)
+        self.save_meeting(meeting)
+    
+    def merge_meetings(self, source_id: str, target_id: str) -> Tuple[Meeting, int]:
+        """
+        Merge the source meeting into the target and delete the source.
+        
+        If the source can't be deleted, the target is restored so nothing is duplicated.
+        
+        Returns:
+            (target, skipped): The merged meeting, and how many same-day updates were dropped
+        """
+        source = self.get_meeting(source_id)
+        target = self.get_open_meeting(target_id)
+        original = target.to_dict()
+        
+        skipped = target.merge_from(source)
+        self.save_meeting(target)
+        if not self.delete_meeting(source_id):
+            self.save_meeting(Meeting.from_dict(original))
+            raise OSError(f"Could not delete meeting {source_id} after merging it")
+        return target, skipped
+    
     def meeting_exists(self, meeting_id: str) -> bool:
         """Check if a meeting exists."""
         meeting_path = self._get_meeting_path(meeting_id)
@@ -59,8 +217,305 @@ class MeetingStorage:
         
         return meeting_ids
     
+    def append_audit_event(self, event: AuditEvent) -> None:
+        """Append an event to a meeting's audit log. Entries are never rewritten."""
+        audit_path = self._get_audit_path(event.meeting_id)
+        
+        with open(audit_path, 'a', encoding='utf-8') as f:
+            f.write(json.dumps(asdict(event), ensure_ascii=False) + "\n")
+    
+    def load_audit_events(self, meeting_id: str) -> List[AuditEvent]:
+        """Load a meeting's audit log in the order events were recorded."""
+        audit_path = self._get_audit_path(meeting_id)
+        
+        if not audit_path.exists():
+            return []
+        
+        events = []
+        with open(audit_path, 'r', encoding='utf-8') as f:
+            for line in f:
+                if not line.strip():
+                    continue
+                try:
+                    events.append(AuditEvent(**json.loads(line)))
+                except (json.JSONDecodeError, TypeError) as e:
+                    log(f"Error reading audit event for meeting {meeting_id}: {e}")
+        
+        return events
+    
+    def _get_changes_path(self, meeting_id: str) -> Path:
+        """Get the file path for a meeting's field change history."""
+        meeting_dir = self.storage_dir / meeting_id
+        meeting_dir.mkdir(exist_ok=True)
+        return meeting_dir / "changes.jsonl"
+    
+    def append_meeting_change(self, change: MeetingChange) -> None:
+        """Append an edit to a meeting's change history."""
+        with open(self._get_changes_path(change.meeting_id), 'a', encoding='utf-8') as f:
+            f.write(json.dumps(asdict(change), ensure_ascii=False) + "\n")
+    
+    def load_meeting_changes(self, meeting_id: str) -> List[MeetingChange]:
+        """Load a meeting's edits, oldest first."""
+        changes_path = self._get_changes_path(meeting_id)
+        
+        if not changes_path.exists():
+            return []
+        
+        changes = []
+        with open(changes_path, 'r', encoding='utf-8') as f:
+            for line in f:
+                if not line.strip():
+                    continue
+                try:
+                    changes.append(MeetingChange.from_dict(json.loads(line)))
+                except (json.JSONDecodeError, KeyError, TypeError) as e:
+                    log(f"Error reading change for meeting {meeting_id}: {e}")
+        
+        return changes
+    
+    def load_user_prefs(self, user_id: int) -> UserPreferences:
+        """Load a user's preferences, falling back to defaults."""
+        prefs_path = self._get_user_prefs_path(user_id)
+        
+        if not prefs_path.exists():
+            return UserPreferences(user_id=user_id)
+        
+        try:
+            with open(prefs_path, 'r', encoding='utf-8') as f:
+                data = json.load(f)
+            return UserPreferences.from_dict(data)
+        except (json.JSONDecodeError, TypeError) as e:
+            log(f"Error loading preferences for user {user_id}: {e}")
+            return UserPreferences(user_id=user_id)
+    
+    def save_user_prefs(self, prefs: UserPreferences) -> None:
+        """Save a user's preferences."""
+        prefs_path = self._get_user_prefs_path(prefs.user_id)
+        
+        with open(prefs_path, 'w', encoding='utf-8') as f:
+            json.dump(prefs.to_dict(), f, indent=2, ensure_ascii=False)
+    
+    def load_guild_settings(self, guild_id: int) -> GuildSettings:
+        """Load a server's settings, falling back to defaults."""
+        settings_path = self._get_guild_settings_path(guild_id)
+        
+        if not settings_path.exists():
+            return GuildSettings(guild_id=guild_id)
+        
+        try:
+            with open(settings_path, 'r', encoding='utf-8') as f:
+                data = json.load(f)
+            return GuildSettings.from_dict(data)
+        except (json.JSONDecodeError, TypeError) as e:
+            log(f"Error loading settings for guild {guild_id}: {e}")
+            return GuildSettings(guild_id=guild_id)
+    
+    def save_guild_settings(self, settings: GuildSettings) -> None:
+        """Save a server's settings."""
+        settings_path = self._get_guild_settings_path(settings.guild_id)
+        
+        with open(settings_path, 'w', encoding='utf-8') as f:
+            json.dump(settings.to_dict(), f, indent=2, ensure_ascii=False)
+    
+    def load_channel_settings(self, channel_id: int) -> ChannelSettings:
+        """Load a channel's setting overrides, falling back to none."""
+        settings_path = self._get_channel_settings_path(channel_id)
+        
+        if not settings_path.exists():
+            return ChannelSettings(channel_id=channel_id)
+        
+        try:
+            with open(settings_path, 'r', encoding='utf-8') as f:
+                data = json.load(f)
+            return ChannelSettings.from_dict(data)
+        except (json.JSONDecodeError, TypeError) as e:
+            log(f"Error loading settings for channel {channel_id}: {e}")
+            return ChannelSettings(channel_id=channel_id)
+    
+    def save_channel_settings(self, settings: ChannelSettings) -> None:
+        """Save a channel's setting overrides."""
+        settings_path = self._get_channel_settings_path(settings.channel_id)
+        
+        with open(settings_path, 'w', encoding='utf-8') as f:
+            json.dump(settings.to_dict(), f, indent=2, ensure_ascii=False)
+    
+    def save_announcement(self, announcement: Announcement) -> None:
+        """Save a scheduled announcement."""
+        announcement_path = self._get_announcement_path(announcement.id)
+        
+        with open(announcement_path, 'w', encoding='utf-8') as f:
+            json.dump(announcement.to_dict(), f, indent=2, ensure_ascii=False)
+    
+    def load_announcement(self, announcement_id: str) -> Optional[Announcement]:
+        """Load a scheduled announcement, or None if it was posted, cancelled, or never existed."""
+        announcement_path = self._get_announcement_path(announcement_id)
+        
+        if not announcement_path.exists():
+            return None
+        
+        try:
+            with open(announcement_path, 'r', encoding='utf-8') as f:
+                data = json.load(f)
+            return Announcement.from_dict(data)
+        except (json.JSONDecodeError, TypeError) as e:
+            log(f"Error loading announcement {announcement_id}: {e}")
+            return None
+    
+    def iter_announcements(self) -> Iterator[Announcement]:
+        """Yield scheduled announcements one at a time."""
+        announcements_dir = self.storage_dir / "_announcements"
+        if not announcements_dir.exists():
+            return
+        for announcement_path in announcements_dir.glob("*.json"):
+            announcement = self.load_announcement(announcement_path.stem)
+            if announcement:
+                yield announcement
+    
+    def find_announcements(self, guild_id: int) -> List[Announcement]:
+        """Find a server's scheduled announcements, soonest first."""
+        announcements = [announcement for announcement in self.iter_announcements() if announcement.guild_id == guild_id]
+        announcements.sort(key=lambda announcement: datetime.fromisoformat(announcement.post_at))
+        return announcements
+    
+    def find_due_announcements(self, now: datetime) -> List[Announcement]:
+        """Find announcements in every server whose time has come, oldest first."""
+        announcements = [announcement for announcement in self.iter_announcements() if announcement.is_due(now)]
+        announcements.sort(key=lambda announcement: datetime.fromisoformat(announcement.post_at))
+        return announcements
+    
+    def delete_announcement(self, announcement_id: str) -> bool:
+        """Delete a scheduled announcement, returning False if it no longer exists."""
+        announcement_path = self._get_announcement_path(announcement_id)
+        try:
+            announcement_path.unlink()
+            return True
+        except FileNotFoundError:
+            return False
+    
+    def iter_meetings(self) -> Iterator[Meeting]:
+        """Yield stored meetings one at a time."""
+        for meeting_id in self.list_meetings():
+            meeting = self.load_meeting(meeting_id)
+            if meeting:
+                yield meeting
+    
+    def find_meetings(self, guild_id: Optional[int], tag: Optional[str] = None, archived: bool = False) -> List[Meeting]:
+        """Find a guild's meetings, newest first, optionally limited to a tag.
+        
+        Archived meetings are only returned when `archived` is True, and then exclusively.
+        A None guild (e.g. a DM) matches nothing rather than every guild.
+        """
+        if guild_id is None:
+            return []
+        
+        def load():
+            meetings = [
+                meeting for meeting in self.iter_meetings()
+                if meeting.guild_id == guild_id
+                and (tag is None or tag in meeting.tags)
+                and meeting.is_archived == archived
+            ]
+            meetings.sort(key=lambda meeting: meeting.created_at, reverse=True)
+            return meetings
+        
+        # Copy so callers can't change what later hits see
+        return list(self.cache.get_or_load(('find_meetings', guild_id, tag, archived), load))
+    
+    def find_meetings_between(self, guild_id: Optional[int], start: datetime, end: datetime) -> List[Meeting]:
+        """Find a guild's meetings created in [start, end), oldest first, including archived ones. A None guild matches nothing."""
+        if guild_id is None:
+            return []
+        meetings = [
+            meeting for meeting in self.iter_meetings()
+            if meeting.guild_id == guild_id
+            and start <= datetime.fromisoformat(meeting.created_at) < end
+        ]
+        meetings.sort(key=lambda meeting: meeting.created_at)
+        return meetings
+    
+    def find_user_meetings(self, user: str) -> Tuple[List[Meeting], List[Meeting]]:
+        """Find meetings a user created and meetings they submitted updates to."""
+        created, participated = [], []
+        for meeting in self.iter_meetings():
+            if meeting.created_by == user:
+                created.append(meeting)
+            elif any(update.user == user for update in meeting.updates):
+                participated.append(meeting)
+        return created, participated
+    
+    def count_open_meetings(self) -> int:
+        """Count meetings that have not been closed or archived, leaving out ones awaiting approval."""
+        return self.cache.get_or_load(
+            'count_open_meetings',
+            lambda: sum(1 for meeting in self.iter_meetings() if not (meeting.is_closed or meeting.is_archived or meeting.is_pending))
+        )
+    
+    def _get_command_hashes_path(self) -> Path:
+        """Get the file path for the last synced command hashes."""
+        return self.storage_dir / "command_hashes.json"
+    
+    def _load_command_hashes(self) -> dict:
+        """Load all last synced command hashes keyed by scope."""
+        hashes_path = self._get_command_hashes_path()
+        if not hashes_path.exists():
+            return {}
+        try:
+            with open(hashes_path, 'r', encoding='utf-8') as f:
+                return json.load(f)
+        except json.JSONDecodeError as e:
+            log(f"Error loading command hashes: {e}")
+            return {}
+    
+    def load_command_hash(self, scope: str) -> Optional[str]:
+        """Get the hash of the commands last synced to a scope (a guild ID or "global")."""
+        return self._load_command_hashes().get(scope)
+    
+    def save_command_hash(self, scope: str, command_hash: str) -> None:
+        """Record the hash of the commands just synced to a scope."""
+        hashes = self._load_command_hashes()
+        hashes[scope] = command_hash
+        with open(self._get_command_hashes_path(), 'w', encoding='utf-8') as f:
+            json.dump(hashes, f, indent=2)
+    
+    def _get_digest_messages_path(self) -> Path:
+        """Get the file path for the pinned digest message IDs."""
+        return self.storage_dir / "digest_messages.json"
+    
+    def load_digest_message_id(self, channel_id: int) -> Optional[int]:
+        """Get the ID of the pinned digest message kept up to date in a channel, if any."""
+        messages_path = self._get_digest_messages_path()
+        if not messages_path.exists():
+            return None
+        try:
+            with open(messages_path, 'r', encoding='utf-8') as f:
+                return json.load(f).get(str(channel_id))
+        except json.JSONDecodeError as e:
+            log(f"Error loading digest messages: {e}")
+            return None
+    
+    def save_digest_message_id(self, channel_id: int, message_id: int) -> None:
+        """Record the pinned digest message for a channel."""
+        messages_path = self._get_digest_messages_path()
+        messages = {}
+        if messages_path.exists():
+            try:
+                with open(messages_path, 'r', encoding='utf-8') as f:
+                    messages = json.load(f)
+            except json.JSONDecodeError as e:
+                log(f"Error loading digest messages: {e}")
+        messages[str(channel_id)] = message_id
+        with open(messages_path, 'w', encoding='utf-8') as f:
+            json.dump(messages, f, indent=2)
+    
+    def purge_guild(self, guild_id: int) -> int:
+        """Delete every meeting and scheduled announcement from a server, returning how many meetings were removed. Audit logs are kept."""
+        for announcement in self.find_announcements(guild_id):
+            self.delete_announcement(announcement.id)
+        meeting_ids = [meeting.id for meeting in self.iter_meetings() if meeting.guild_id == guild_id]
+        return sum(1 for meeting_id in meeting_ids if self.delete_meeting(meeting_id))
+    
     def delete_meeting(self, meeting_id: str) -> bool:
-        """Delete a meeting and its directory."""
+        """Delete a meeting and its directory. The audit log is always kept."""
         meeting_path = self._get_meeting_path(meeting_id)
         
         if not meeting_path.exists():
@@ -69,14 +524,15 @@ class MeetingStorage:
         try:
             # Delete the meeting file
             meeting_path.unlink()
+            self.cache.invalidate()
             
-            # Delete the meeting directory if it's empty
+            # Delete the meeting directory if it's empty (audit.jsonl keeps it around)
             meeting_dir = meeting_path.parent
             if meeting_dir.exists() and not any(meeting_dir.iterdir()):
                 meeting_dir.rmdir()
             
             return True
         except OSError as e:
-            print(f"Error deleting meeting {meeting_id}: {e}")
+            log(f"Error deleting meeting {meeting_id}: {e}")
             return False
 
@@ -0,0 +1,29 @@
+"""
+Lightweight request tracing so log lines from one interaction can be correlated.
+"""
+import uuid
+from contextvars import ContextVar
+from typing import Optional
+
+_request_id: ContextVar[Optional[str]] = ContextVar('request_id', default=None)
+
+
+def start_request() -> str:
+    """Generate a request ID for the current interaction and bind it to this context."""
+    request_id = uuid.uuid4().hex[:8]
+    _request_id.set(request_id)
+    return request_id
+
+
+def get_request_id() -> Optional[str]:
+    """Get the request ID bound to the current context, if any."""
+    return _request_id.get()
+
+
+def log(message: str):
+    """Print a log line, prefixed with the current request ID when there is one."""
+    request_id = _request_id.get()
+    if request_id:
+        print(f"[{request_id}] {message}")
+    else:
+        print(message)
@@ -0,0 +1,44 @@
+"""
+Base view whose buttons can be recognised as expired after the view is gone.
+"""
+import uuid
+from typing import Optional, Set
+
+import discord
+
+EXPIRING_CUSTOM_ID_PREFIX = "meetingbot:view:"
+
+# Tokens of views still listening in this process; lost on restart, which is the point
+_live_tokens: Set[str] = set()
+
+
+class ExpiringView(discord.ui.View):
+    """View that stamps a per-instance token into its components' custom IDs.
+
+    Clicks on a component whose token is no longer live (timed out, stopped, or
+    from before a restart) can then be answered instead of silently failing.
+    """
+
+    def __init__(self, timeout: Optional[float] = 180):
+        super().__init__(timeout=timeout)
+        self.token = uuid.uuid4().hex[:12]
+        _live_tokens.add(self.token)
+        for index, item in enumerate(self.children):
+            item.custom_id = f"{EXPIRING_CUSTOM_ID_PREFIX}{self.token}:{index}"
+
+    def stop(self):
+        """Stop listening and forget this view's token."""
+        _live_tokens.discard(self.token)
+        super().stop()
+
+    async def on_timeout(self):
+        """Forget this view's token once it stops listening."""
+        _live_tokens.discard(self.token)
+
+
+def is_expired_custom_id(custom_id: str) -> bool:
+    """Check whether a custom ID belongs to an ExpiringView that is no longer live."""
+    if not custom_id.startswith(EXPIRING_CUSTOM_ID_PREFIX):
+        return False
+    token = custom_id[len(EXPIRING_CUSTOM_ID_PREFIX):].split(':', 1)[0]
+    return token not in _live_tokens
@@ -0,0 +1,3 @@
+"""
+Tests for the meeting bot. Run with `python -m unittest discover tests` from the repository root.
+"""
@@ -0,0 +1,34 @@
+"""
+Stand-ins for the Discord objects handlers receive, so they can be called without a gateway connection.
+"""
+from unittest.mock import AsyncMock, MagicMock
+
+import discord
+
+
+def make_member(name: str, user_id: int = 1, manage_events: bool = False, administrator: bool = False) -> MagicMock:
+    """A server member whose str() is `name`, as meetings store users."""
+    member = MagicMock(spec=discord.Member)
+    member.__str__.return_value = name
+    member.id = user_id
+    member.bot = False
+    member.guild_permissions = discord.Permissions(manage_events=manage_events, administrator=administrator)
+    return member
+
+
+def make_interaction(user: MagicMock, guild_id: int) -> MagicMock:
+    """An interaction from `user` in a server that hasn't been responded to yet."""
+    interaction = MagicMock()
+    interaction.user = user
+    interaction.guild_id = guild_id
+    interaction.response.is_done.return_value = False
+    interaction.response.send_message = AsyncMock()
+    interaction.response.send_modal = AsyncMock()
+    interaction.response.edit_message = AsyncMock()
+    interaction.followup.send = AsyncMock()
+    return interaction
+
+
+def sent_content(interaction: MagicMock) -> str:
+    """The text of the interaction's first response."""
+    return interaction.response.send_message.call_args.kwargs.get('content', "")
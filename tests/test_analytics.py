@@ -0,0 +1,37 @@
+"""
+Tests for attendance taken from a meeting's roster and updates.
+"""
+import unittest
+
+from src.analytics import compute_attendance
+from src.models import Meeting
+from tests.test_models import add_update
+
+
+class ComputeAttendanceTests(unittest.TestCase):
+    
+    def setUp(self):
+        self.meeting = Meeting.create_new(created_by="alice", name="Standup", link="")
+        self.meeting.roster = ["bob", "carol"]
+        add_update(self.meeting, "bob")
+        add_update(self.meeting, "dave")
+    
+    def test_lists_the_roster_then_other_posters(self):
+        records = compute_attendance(self.meeting)
+        
+        self.assertEqual(
+            [(record.user, record.on_roster, record.updates) for record in records],
+            [("bob", True, 1), ("carol", True, 0), ("dave", False, 1)]
+        )
+    
+    def test_anonymous_meetings_list_only_the_roster_without_who_posted(self):
+        self.meeting.anonymous_updates = True
+        
+        records = compute_attendance(self.meeting)
+        
+        self.assertEqual([record.user for record in records], ["bob", "carol"])
+        self.assertTrue(all(record.updates is None and record.first_update_at is None for record in records))
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,100 @@
+"""
+Tests for scheduled announcements.
+"""
+import tempfile
+import unittest
+from datetime import datetime, timedelta, timezone
+
+from src.models import Announcement
+from src.storage import MeetingStorage
+
+NOW = datetime(2024, 6, 1, 9, 30, tzinfo=timezone.utc)
+
+
+class AnnouncementTestCase(unittest.TestCase):
+    
+    def setUp(self):
+        self._tmp = tempfile.TemporaryDirectory()
+        self.addCleanup(self._tmp.cleanup)
+        self.storage = MeetingStorage(self._tmp.name)
+    
+    def schedule(self, post_at: datetime, guild_id: int = 1, message: str = "Hello") -> Announcement:
+        announcement = Announcement.create_new(guild_id=guild_id, channel_id=10, created_by="alice", message=message, post_at=post_at)
+        self.storage.save_announcement(announcement)
+        return announcement
+
+
+class SchedulingTests(AnnouncementTestCase):
+    
+    def test_scheduled_announcements_are_listed_soonest_first(self):
+        later = self.schedule(NOW + timedelta(hours=2))
+        sooner = self.schedule(NOW + timedelta(hours=1))
+        
+        self.assertEqual([a.id for a in self.storage.find_announcements(1)], [sooner.id, later.id])
+    
+    def test_listing_is_limited_to_the_server(self):
+        self.schedule(NOW, guild_id=1)
+        other = self.schedule(NOW, guild_id=2)
+        
+        self.assertEqual([a.id for a in self.storage.find_announcements(2)], [other.id])
+    
+    def test_times_without_a_timezone_are_refused(self):
+        with self.assertRaises(ValueError):
+            Announcement.create_new(guild_id=1, channel_id=10, created_by="alice", message="Hi", post_at=NOW.replace(tzinfo=None))
+
+
+class DeliveryTimingTests(AnnouncementTestCase):
+    
+    def test_nothing_is_due_before_its_time(self):
+        self.schedule(NOW + timedelta(minutes=1))
+        
+        self.assertEqual(self.storage.find_due_announcements(NOW), [])
+    
+    def test_announcement_is_due_at_and_after_its_time(self):
+        announcement = self.schedule(NOW)
+        
+        self.assertEqual([a.id for a in self.storage.find_due_announcements(NOW)], [announcement.id])
+        self.assertEqual([a.id for a in self.storage.find_due_announcements(NOW + timedelta(hours=1))], [announcement.id])
+    
+    def test_due_announcements_from_every_server_are_returned_oldest_first(self):
+        newer = self.schedule(NOW - timedelta(minutes=1), guild_id=1)
+        older = self.schedule(NOW - timedelta(minutes=5), guild_id=2)
+        self.schedule(NOW + timedelta(minutes=5), guild_id=1)
+        
+        self.assertEqual([a.id for a in self.storage.find_due_announcements(NOW)], [older.id, newer.id])
+    
+    def test_other_timezones_compare_by_instant(self):
+        # 11:30 in UTC+2 is 09:30 UTC
+        announcement = self.schedule(datetime(2024, 6, 1, 11, 30, tzinfo=timezone(timedelta(hours=2))))
+        
+        self.assertTrue(announcement.is_due(NOW))
+        self.assertFalse(announcement.is_due(NOW - timedelta(seconds=1)))
+
+
+class CancellationTests(AnnouncementTestCase):
+    
+    def test_cancelled_announcement_is_never_due(self):
+        announcement = self.schedule(NOW)
+        
+        self.assertTrue(self.storage.delete_announcement(announcement.id))
+        
+        self.assertIsNone(self.storage.load_announcement(announcement.id))
+        self.assertEqual(self.storage.find_due_announcements(NOW + timedelta(days=1)), [])
+    
+    def test_cancelling_twice_reports_it_was_already_gone(self):
+        announcement = self.schedule(NOW)
+        self.storage.delete_announcement(announcement.id)
+        
+        self.assertFalse(self.storage.delete_announcement(announcement.id))
+    
+    def test_purging_a_server_cancels_its_announcements(self):
+        self.schedule(NOW, guild_id=1)
+        kept = self.schedule(NOW, guild_id=2)
+        
+        self.storage.purge_guild(1)
+        
+        self.assertEqual([a.id for a in self.storage.find_due_announcements(NOW)], [kept.id])
+
+
+if __name__ == '__main__':
+    unittest.main()
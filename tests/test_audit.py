@@ -0,0 +1,38 @@
+"""
+Tests for recording audit events from handlers.
+"""
+import tempfile
+import unittest
+from unittest import mock
+
+from src.bot import bot, record_audit
+from src.models import ANONYMOUS_AUTHOR
+from src.storage import MeetingStorage
+from tests.fakes import make_interaction, make_member
+
+
+class RecordAuditTests(unittest.IsolatedAsyncioTestCase):
+    
+    def setUp(self):
+        self._tmp = tempfile.TemporaryDirectory()
+        self.addCleanup(self._tmp.cleanup)
+        patcher = mock.patch.object(bot, 'storage', MeetingStorage(self._tmp.name))
+        self.storage = patcher.start()
+        self.addCleanup(patcher.stop)
+        self.interaction = make_interaction(make_member("bob"), 1)
+    
+    async def test_records_the_actor(self):
+        await record_audit("m1", "update", self.interaction)
+        
+        [event] = self.storage.load_audit_events("m1")
+        self.assertEqual((event.event_type, event.actor), ("update", "bob"))
+    
+    async def test_anonymous_updates_leave_the_actor_out(self):
+        await record_audit("m1", "update", self.interaction, anonymous=True)
+        
+        [event] = self.storage.load_audit_events("m1")
+        self.assertEqual(event.actor, ANONYMOUS_AUTHOR)
+
+
+if __name__ == '__main__':
+    unittest.main()
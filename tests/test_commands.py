@@ -0,0 +1,35 @@
+"""
+Tests for the registered slash commands.
+"""
+import unittest
+
+import discord
+
+from src.bot import MANAGE_COMMAND_NAME, bot
+
+
+class CommandPermissionTests(unittest.TestCase):
+    
+    def test_manage_command_requires_manage_events(self):
+        command = bot.tree.get_command(MANAGE_COMMAND_NAME)
+        
+        self.assertTrue(command.default_permissions.manage_events)
+        # This is what gets synced, so the bits must reach Discord
+        payload = command.to_dict(bot.tree)
+        self.assertEqual(int(payload['default_member_permissions']), discord.Permissions(manage_events=True).value)
+        self.assertTrue(command.guild_only)
+    
+    def test_main_command_is_open_to_everyone(self):
+        command = bot.tree.get_command("meetingbot")
+        
+        self.assertIsNone(command.to_dict(bot.tree)['default_member_permissions'])
+    
+    def test_manager_actions_are_only_on_the_manage_command(self):
+        self.assertTrue(bot.actions["audit"].manager)
+        self.assertTrue(bot.actions["analytics"].manager)
+        self.assertFalse(bot.actions["update"].manager)
+        self.assertFalse(bot.actions["list"].manager)
+
+
+if __name__ == '__main__':
+    unittest.main()
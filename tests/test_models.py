@@ -0,0 +1,43 @@
+"""
+Tests for the meeting data model.
+"""
+import unittest
+
+from src.models import Meeting
+
+
+def add_update(meeting: Meeting, user: str, progress: str = "Did things"):
+    return meeting.add_update(user=user, progress=progress, blockers="None", goals="More things")
+
+
+class UpdateDedupeTests(unittest.TestCase):
+    
+    def setUp(self):
+        self.meeting = Meeting.create_new(created_by="alice", name="Standup", link="")
+    
+    def test_second_update_on_the_same_day_replaces_the_first(self):
+        add_update(self.meeting, "bob", "First draft")
+        
+        update, replaced = add_update(self.meeting, "bob", "Second draft")
+        
+        self.assertTrue(replaced)
+        self.assertEqual(self.meeting.updates, [update])
+        self.assertEqual(update.progress, "Second draft")
+    
+    def test_updates_from_different_users_are_kept(self):
+        add_update(self.meeting, "bob")
+        
+        _, replaced = add_update(self.meeting, "carol")
+        
+        self.assertFalse(replaced)
+        self.assertEqual([update.user for update in self.meeting.updates], ["bob", "carol"])
+    
+    def test_closed_meetings_refuse_updates(self):
+        self.meeting.is_closed = True
+        
+        with self.assertRaises(ValueError):
+            add_update(self.meeting, "bob")
+
+
+if __name__ == '__main__':
+    unittest.main()
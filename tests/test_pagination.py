@@ -0,0 +1,57 @@
+"""
+Tests for keeping paged and inline change listings within Discord's size limits.
+"""
+import unittest
+
+from src.bot import format_field_changes
+from src.models import FieldChange, MeetingChange
+from src.pagination import EMBED_DESCRIPTION_LIMIT, split_pages
+
+
+class SplitPagesTests(unittest.TestCase):
+    
+    def test_pages_hold_at_most_per_page_lines(self):
+        pages = split_pages([str(i) for i in range(7)], per_page=3)
+        
+        self.assertEqual([len(page) for page in pages], [3, 3, 1])
+    
+    def test_long_lines_start_a_new_page_before_the_limit(self):
+        lines = ["x" * 1500] * 5
+        
+        pages = split_pages(lines, per_page=5)
+        
+        self.assertEqual([len(page) for page in pages], [2, 2, 1])
+        for page in pages:
+            self.assertLessEqual(len("\n".join(page)), EMBED_DESCRIPTION_LIMIT)
+    
+    def test_a_line_over_the_limit_is_cut_short(self):
+        [[line]] = split_pages(["x" * 5000], per_page=5)
+        
+        self.assertEqual(len(line), EMBED_DESCRIPTION_LIMIT)
+        self.assertTrue(line.endswith("…"))
+    
+    def test_no_lines_gives_one_empty_page(self):
+        self.assertEqual(split_pages([], per_page=5), [[]])
+
+
+class FormatFieldChangesTests(unittest.TestCase):
+    
+    def test_every_field_changed_with_long_values_fits_in_a_message(self):
+        change = MeetingChange(
+            meeting_id="m1",
+            changed_by="alice",
+            changed_at="2026-01-01T09:00:00",
+            changes=[
+                FieldChange(field=field, old="a" * 4000, new="b" * 4000)
+                for field in ("name", "link", "location", "tags")
+            ]
+        )
+        
+        text = format_field_changes(change)
+        
+        self.assertLess(len(f"✅ Meeting `{change.meeting_id}` updated:\n{text}"), 2000)
+        self.assertIn("…", text)
+
+
+if __name__ == '__main__':
+    unittest.main()
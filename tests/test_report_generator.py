@@ -0,0 +1,42 @@
+"""
+Tests for the attendance CSV export.
+"""
+import csv
+import io
+import unittest
+
+from src.models import Meeting
+from src.report_generator import ReportGenerator
+from tests.test_models import add_update
+
+
+def read_csv(text: str) -> list:
+    return list(csv.reader(io.StringIO(text)))
+
+
+class AttendanceCsvTests(unittest.TestCase):
+    
+    def setUp(self):
+        self.generator = ReportGenerator()
+        self.meeting = Meeting.create_new(created_by="alice", name="Standup", link="")
+        self.meeting.roster = ["bob", "carol"]
+        add_update(self.meeting, "bob")
+        add_update(self.meeting, "dave")
+    
+    def test_sheet_shows_who_attended(self):
+        header, *rows = read_csv(self.generator.generate_attendance_csv(self.meeting))
+        
+        self.assertIn("attended", header)
+        self.assertEqual([row[2] for row in rows], ["bob", "carol", "dave"])
+    
+    def test_anonymous_sheet_leaves_out_who_posted(self):
+        self.meeting.anonymous_updates = True
+        
+        header, *rows = read_csv(self.generator.generate_attendance_csv(self.meeting))
+        
+        self.assertEqual(header, ["meeting_id", "meeting_name", "member", "on_roster"])
+        self.assertEqual([row[2] for row in rows], ["bob", "carol"])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,83 @@
+"""
+Tests for the JSON meeting storage.
+"""
+import tempfile
+import unittest
+from datetime import datetime, timedelta
+
+from src.models import AuditEvent, Meeting
+from src.storage import MeetingStorage
+
+
+class StorageTestCase(unittest.TestCase):
+    """Gives each test a fresh storage directory."""
+    
+    def setUp(self):
+        self._tmp = tempfile.TemporaryDirectory()
+        self.addCleanup(self._tmp.cleanup)
+        self.storage = MeetingStorage(self._tmp.name)
+    
+    def create_meeting(self, created_by: str = "alice", **attrs) -> Meeting:
+        meeting = Meeting.create_new(created_by=created_by, name="Standup", link="")
+        for name, value in attrs.items():
+            setattr(meeting, name, value)
+        self.storage.create_meeting(meeting)
+        return meeting
+
+
+class AuditLogTests(StorageTestCase):
+    
+    def test_events_are_appended_in_order(self):
+        meeting = self.create_meeting()
+        for event_type in ("create", "update", "close"):
+            self.storage.append_audit_event(AuditEvent.create_new(event_type, "alice", meeting.id))
+        
+        events = self.storage.load_audit_events(meeting.id)
+        
+        self.assertEqual([event.event_type for event in events], ["create", "update", "close"])
+        self.assertTrue(all(event.actor == "alice" and event.meeting_id == meeting.id for event in events))
+    
+    def test_appending_keeps_earlier_events(self):
+        meeting = self.create_meeting()
+        self.storage.append_audit_event(AuditEvent.create_new("create", "alice", meeting.id))
+        first = self.storage.load_audit_events(meeting.id)
+        
+        self.storage.append_audit_event(AuditEvent.create_new("edit", "bob", meeting.id))
+        
+        self.assertEqual(self.storage.load_audit_events(meeting.id)[:1], first)
+    
+    def test_deleting_a_meeting_keeps_its_audit_log(self):
+        meeting = self.create_meeting()
+        self.storage.append_audit_event(AuditEvent.create_new("create", "alice", meeting.id))
+        
+        self.assertTrue(self.storage.delete_meeting(meeting.id))
+        
+        self.assertIsNone(self.storage.load_meeting(meeting.id))
+        self.assertEqual(len(self.storage.load_audit_events(meeting.id)), 1)
+
+
+
+class GuildFilterTests(StorageTestCase):
+    
+    def setUp(self):
+        super().setUp()
+        self.home = self.create_meeting(guild_id=1)
+        self.other = self.create_meeting(guild_id=2)
+        # Saved before meetings recorded their server
+        self.unscoped = self.create_meeting(guild_id=None)
+    
+    def test_find_meetings_only_returns_the_guilds_meetings(self):
+        self.assertEqual([m.id for m in self.storage.find_meetings(1)], [self.home.id])
+    
+    def test_find_meetings_without_a_guild_matches_nothing(self):
+        self.assertEqual(self.storage.find_meetings(None), [])
+    
+    def test_find_meetings_between_only_returns_the_guilds_meetings(self):
+        start, end = datetime.now() - timedelta(days=1), datetime.now() + timedelta(days=1)
+        
+        self.assertEqual([m.id for m in self.storage.find_meetings_between(2, start, end)], [self.other.id])
+        self.assertEqual(self.storage.find_meetings_between(None, start, end), [])
+
+
+if __name__ == '__main__':
+    unittest.main()
@@ -0,0 +1,94 @@
+"""
+Tests for who can see a meeting, including across servers.
+"""
+import tempfile
+import unittest
+from unittest import mock
+
+from src.bot import CopyLinkButton, bot, can_view_meeting
+from src.models import Meeting, PRIVATE, PUBLIC
+from src.storage import MeetingStorage
+from tests.fakes import make_interaction, make_member, sent_content
+
+HOME, OTHER = 1, 2
+
+
+def make_meeting(visibility: str = PRIVATE, guild_id: int = HOME) -> Meeting:
+    meeting = Meeting.create_new(created_by="alice", name="Planning", link="https://example.com/room", visibility=visibility)
+    meeting.guild_id = guild_id
+    return meeting
+
+
+class CanViewMeetingTests(unittest.TestCase):
+    
+    def test_public_meetings_are_visible_to_anyone_in_their_server(self):
+        interaction = make_interaction(make_member("bob"), HOME)
+        
+        self.assertTrue(can_view_meeting(interaction, make_meeting(PUBLIC)))
+    
+    def test_private_meetings_are_visible_to_their_host(self):
+        interaction = make_interaction(make_member("alice"), HOME)
+        
+        self.assertTrue(can_view_meeting(interaction, make_meeting()))
+    
+    def test_private_meetings_are_hidden_from_other_members(self):
+        interaction = make_interaction(make_member("bob"), HOME)
+        
+        self.assertFalse(can_view_meeting(interaction, make_meeting()))
+    
+    def test_managers_see_private_meetings_in_their_own_server(self):
+        interaction = make_interaction(make_member("bob", manage_events=True), HOME)
+        
+        self.assertTrue(can_view_meeting(interaction, make_meeting()))
+    
+    def test_managers_in_another_server_do_not_see_private_meetings(self):
+        interaction = make_interaction(make_member("mallory", manage_events=True), OTHER)
+        
+        self.assertFalse(can_view_meeting(interaction, make_meeting()))
+    
+    def test_meetings_from_another_server_are_hidden_even_when_public(self):
+        interaction = make_interaction(make_member("alice"), OTHER)
+        
+        self.assertFalse(can_view_meeting(interaction, make_meeting(PUBLIC)))
+
+
+class CopyLinkButtonTests(unittest.IsolatedAsyncioTestCase):
+    
+    def setUp(self):
+        self._tmp = tempfile.TemporaryDirectory()
+        self.addCleanup(self._tmp.cleanup)
+        patcher = mock.patch.object(bot, 'storage', MeetingStorage(self._tmp.name))
+        self.storage = patcher.start()
+        self.addCleanup(patcher.stop)
+    
+    async def click(self, meeting: Meeting, interaction):
+        self.storage.create_meeting(meeting)
+        await CopyLinkButton(meeting.id).callback(interaction)
+        return sent_content(interaction)
+    
+    async def test_sends_the_raw_link_privately(self):
+        interaction = make_interaction(make_member("bob"), HOME)
+        
+        content = await self.click(make_meeting(PUBLIC), interaction)
+        
+        self.assertIn("```\nhttps://example.com/room\n```", content)
+        self.assertTrue(interaction.response.send_message.call_args.kwargs['ephemeral'])
+    
+    async def test_hides_private_meetings_from_members_who_cannot_see_them(self):
+        interaction = make_interaction(make_member("bob"), HOME)
+        
+        content = await self.click(make_meeting(), interaction)
+        
+        self.assertNotIn("example.com", content)
+        self.assertIn("not found", content)
+    
+    async def test_hides_meetings_from_managers_in_another_server(self):
+        interaction = make_interaction(make_member("mallory", manage_events=True), OTHER)
+        
+        content = await self.click(make_meeting(), interaction)
+        
+        self.assertNotIn("example.com", content)
+
+
+if __name__ == '__main__':
+    unittest.main()